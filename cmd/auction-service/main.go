@@ -12,7 +12,11 @@ import (
 
 	"troffee-auction-service/internal/adapters/broadcaster"
 	"troffee-auction-service/internal/adapters/db"
+	"troffee-auction-service/internal/adapters/fx"
+	"troffee-auction-service/internal/adapters/grpcapi"
+	"troffee-auction-service/internal/adapters/payment"
 	"troffee-auction-service/internal/adapters/redis"
+	"troffee-auction-service/internal/adapters/rest"
 	"troffee-auction-service/internal/adapters/scheduler"
 	"troffee-auction-service/internal/adapters/ws"
 	"troffee-auction-service/internal/app"
@@ -43,12 +47,22 @@ func main() {
 
 	log.Info().Msg("Database connection established")
 
+	// Display conversions and credit checks use a static rate table until a live rate feed is plugged in
+	fxRates := fx.NewStaticRateProvider(fx.StaticRateProviderParams{
+		Base:   cfg.FX.Base,
+		Rates:  cfg.FX.Rates,
+		Logger: log.Logger,
+	})
+
 	// Create repositories
-	repoFactory := db.NewRepositoryFactory(dbConn)
+	repoFactory := db.NewRepositoryFactory(dbConn, fxRates)
 	auctionRepo := repoFactory.GetAuctionRepository()
 	bidRepo := repoFactory.GetBidRepository()
 	itemRepo := repoFactory.GetItemRepository()
 	userRepo := repoFactory.GetUserRepository()
+	offerRepo := repoFactory.GetSecondChanceOfferRepository()
+	orderRepo := repoFactory.GetOrderRepository()
+	ledgerRepo := repoFactory.GetLedgerRepository()
 
 	log.Info().Msg("Database repositories initialized")
 
@@ -61,25 +75,60 @@ func main() {
 
 	// Create Redis broadcaster
 	redisBroadcaster := broadcaster.NewBroadcaster(broadcaster.RedisBroadcasterParams{
-		RedisClient: redisClient,
-		Logger:      log.Logger,
+		RedisClient:  redisClient,
+		EventHistory: cfg.Redis.EventHistory,
+		Logger:       log.Logger,
 	})
 	log.Info().Msg("Redis broadcaster initialized")
 
+	// Payments are collected by the in-process fake gateway until a real provider is plugged in
+	paymentGateway := payment.NewFakeGateway(payment.FakeGatewayParams{
+		DeclineAbove: cfg.Payment.FakeDeclineAbove,
+		Logger:       log.Logger,
+	})
+
 	// Create business services
+	userService := app.NewUserService(app.UserServiceParams{
+		UserRepo: userRepo,
+		Logger:   log.Logger,
+	})
+	ledgerService := app.NewLedgerService(app.LedgerServiceParams{
+		LedgerRepo: ledgerRepo,
+		UserRepo:   userRepo,
+		Logger:     log.Logger,
+	})
+	settlementService := app.NewSettlementService(app.SettlementServiceParams{
+		OrderRepo:     orderRepo,
+		AuctionRepo:   auctionRepo,
+		Gateway:       paymentGateway,
+		Broadcaster:   redisBroadcaster,
+		Ledger:        ledgerService,
+		PaymentWindow: cfg.Payment.Window,
+		BuyerPremium:  cfg.Payment.BuyerPremium,
+		SellerFee:     cfg.Payment.SellerFee,
+		Logger:        log.Logger,
+	})
 	auctionService := app.NewAuctionService(app.AuctionServiceParams{
 		AuctionRepo: auctionRepo,
 		ItemRepo:    itemRepo,
 		UserRepo:    userRepo,
 		BidRepo:     bidRepo,
+		OfferRepo:   offerRepo,
+		Broadcaster: redisBroadcaster,
+		Settlement:  settlementService,
+		OfferTTL:    cfg.Auction.SecondChanceOfferTTL,
 		Logger:      log.Logger,
 	})
 	bidService := app.NewBidService(app.BidServiceParams{
-		BidRepo:     bidRepo,
-		AuctionRepo: auctionRepo,
-		UserRepo:    userRepo,
-		Broadcaster: redisBroadcaster,
-		Logger:      log.Logger,
+		BidRepo:         bidRepo,
+		AuctionRepo:     auctionRepo,
+		UserRepo:        userRepo,
+		Broadcaster:     redisBroadcaster,
+		Settlement:      settlementService,
+		FXRates:         fxRates,
+		BuyNowThreshold: cfg.Auction.BuyNowThreshold,
+		RetractWindow:   cfg.Auction.BidRetractionWindow,
+		Logger:          log.Logger,
 	})
 
 	log.Info().Msg("Business services initialized")
@@ -87,10 +136,14 @@ func main() {
 	// Create auction scheduler
 	auctionScheduler := scheduler.NewAuctionScheduler(
 		scheduler.AuctionSchedulerParams{
-			RedisClient:    redisClient,
-			AuctionService: auctionService,
-			Broadcaster:    redisBroadcaster,
-			Logger:         log.Logger,
+			RedisClient:      redisClient,
+			AuctionService:   auctionService,
+			StartService:     auctionService,
+			PriceDropService: auctionService,
+			RelistService:    auctionService,
+			OrderService:     settlementService,
+			Broadcaster:      redisBroadcaster,
+			Logger:           log.Logger,
 		},
 	)
 
@@ -98,15 +151,30 @@ func main() {
 	auctionScheduler.Start()
 	log.Info().Msg("Auction scheduler started")
 
-	// Update auction service with scheduler
+	// Update services with scheduler
 	auctionService.SetScheduler(auctionScheduler)
+	bidService.SetScheduler(auctionScheduler)
+	settlementService.SetScheduler(auctionScheduler)
+
+	// The HTTP API is served on the same port as the WebSocket endpoint
+	restHandler := rest.NewHandler(rest.HandlerParams{
+		AuctionService:  auctionService,
+		BidService:      bidService,
+		BuyNowThreshold: cfg.Auction.BuyNowThreshold,
+		Logger:          log.Logger,
+	})
 
 	wsServer := ws.NewServer(ws.ServerParams{
-		Config:         cfg,
-		AuctionService: auctionService,
-		BidService:     bidService,
-		Broadcaster:    redisBroadcaster,
-		Logger:         log.Logger,
+		Config:            cfg,
+		AuctionService:    auctionService,
+		BidService:        bidService,
+		SettlementService: settlementService,
+		LedgerService:     ledgerService,
+		UserService:       userService,
+		Broadcaster:       redisBroadcaster,
+		FXRates:           fxRates,
+		Routes:            []ws.RouteRegistrar{restHandler},
+		Logger:            log.Logger,
 	})
 
 	log.Info().Msg("WebSocket server initialized")
@@ -120,6 +188,22 @@ func main() {
 		}
 	}()
 
+	// Internal services reach the same services over gRPC on their own port
+	grpcServer := grpcapi.NewServer(grpcapi.ServerParams{
+		Config:         cfg,
+		AuctionService: auctionService,
+		BidService:     bidService,
+		Broadcaster:    redisBroadcaster,
+		Logger:         log.Logger,
+	})
+
+	go func() {
+		if err := grpcServer.Start(); err != nil {
+			log.Error().Err(err).Msg("Failed to start gRPC server")
+			cancel()
+		}
+	}()
+
 	// Wait for interrupt signal
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -146,6 +230,11 @@ func main() {
 		log.Error().Err(err).Msg("Error stopping WebSocket server")
 	}
 
+	// Stop gRPC server
+	if err := grpcServer.Stop(shutdownCtx); err != nil {
+		log.Error().Err(err).Msg("Error stopping gRPC server")
+	}
+
 	log.Info().Msg("Graceful shutdown completed")
 }
 
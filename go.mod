@@ -3,14 +3,18 @@ module troffee-auction-service
 go 1.22.2
 
 require (
+	github.com/alitto/pond v1.9.2
 	github.com/google/uuid v1.6.0
 	github.com/gorilla/websocket v1.5.3
 	github.com/lib/pq v1.10.9
+	github.com/redis/go-redis/v9 v9.12.0
+	github.com/rs/zerolog v1.34.0
 	github.com/spf13/viper v1.20.1
+	google.golang.org/grpc v1.68.1
+	google.golang.org/protobuf v1.36.1
 )
 
 require (
-	github.com/alitto/pond v1.9.2 // indirect
 	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
 	github.com/fsnotify/fsnotify v1.8.0 // indirect
@@ -18,8 +22,6 @@ require (
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.19 // indirect
 	github.com/pelletier/go-toml/v2 v2.2.3 // indirect
-	github.com/redis/go-redis/v9 v9.12.0 // indirect
-	github.com/rs/zerolog v1.34.0 // indirect
 	github.com/sagikazarmark/locafero v0.7.0 // indirect
 	github.com/sourcegraph/conc v0.3.0 // indirect
 	github.com/spf13/afero v1.12.0 // indirect
@@ -28,7 +30,13 @@ require (
 	github.com/subosito/gotenv v1.6.0 // indirect
 	go.uber.org/atomic v1.9.0 // indirect
 	go.uber.org/multierr v1.9.0 // indirect
+	golang.org/x/net v0.33.0 // indirect
 	golang.org/x/sys v0.29.0 // indirect
 	golang.org/x/text v0.21.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20241223144023-3abc09e42ca8 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+// afero (through viper) still requires the pre-split genproto module, which makes
+// google.golang.org/genproto/googleapis/rpc/status, imported by grpc, ambiguous
+exclude google.golang.org/genproto v0.0.0-20241118233622-e639e219e697
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"troffee-auction-service/internal/domain/shared"
 	"troffee-auction-service/internal/ports/outbound"
 
 	"github.com/google/uuid"
@@ -14,22 +15,43 @@ import (
 	"github.com/rs/zerolog"
 )
 
-// RedisBroadcaster implements the broadcaster interface using Redis pub/sub
+// RedisBroadcaster implements the broadcaster interface using Redis pub/sub.
+// The local event channels belong to their subscribers and are never closed here.
 type RedisBroadcaster struct {
 	client           *redis.Client
 	subscribers      map[string]chan outbound.Event // clientID -> local channel
 	pubsubs          map[string]*redis.PubSub       // clientID -> pubsub instance
-	clientsToAuction map[string]map[string]bool     // clientID -> auctionID -> subscribed
+	listeners        map[string]*listener           // clientID -> goroutine forwarding to the local channel
+	clientsToAuction map[string]map[string]bool     // clientID -> auctionID (or user channel) -> subscribed
+	watches          map[uuid.UUID]*auctionWatch    // auctionID -> subscription shared by its watchers
+	eventHistory     int64
 	mu               sync.RWMutex
+	watchMu          sync.Mutex
 	ctx              context.Context
 	cancel           context.CancelFunc
 	logger           zerolog.Logger
 }
 type RedisBroadcasterParams struct {
 	RedisClient *redis.Client
-	Logger      zerolog.Logger
+	// EventHistory is how many recent events are kept per auction for EventsSince; zero keeps none
+	EventHistory int64
+	Logger       zerolog.Logger
 }
 
+// listener controls the goroutine forwarding a client's Redis messages to its local channel
+type listener struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// eventHistoryTTL drops the event history of auctions nobody has published to for a while
+const eventHistoryTTL = 24 * time.Hour
+
+// eventSequenceTTL drops the event counter of auctions nobody has published to for a long while.
+// It outlasts the history so that sequence numbers are not reused while clients may still
+// remember them.
+const eventSequenceTTL = 30 * 24 * time.Hour
+
 func NewBroadcaster(params RedisBroadcasterParams) *RedisBroadcaster {
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -37,7 +59,10 @@ func NewBroadcaster(params RedisBroadcasterParams) *RedisBroadcaster {
 		client:           params.RedisClient,
 		subscribers:      make(map[string]chan outbound.Event),
 		pubsubs:          make(map[string]*redis.PubSub),
+		listeners:        make(map[string]*listener),
 		clientsToAuction: make(map[string]map[string]bool),
+		watches:          make(map[uuid.UUID]*auctionWatch),
+		eventHistory:     params.EventHistory,
 		ctx:              ctx,
 		cancel:           cancel,
 		logger:           params.Logger.With().Str("component", "redis_broadcaster").Logger(),
@@ -48,15 +73,25 @@ func NewBroadcaster(params RedisBroadcasterParams) *RedisBroadcaster {
 
 // Subscribe subscribes a client to events for a specific auction
 func (redisClient *RedisBroadcaster) Subscribe(ctx context.Context, auctionID uuid.UUID, clientID string, eventChan chan outbound.Event) error {
+	return redisClient.subscribe(ctx, clientID, auctionID.String(), auctionChannel(auctionID), eventChan)
+}
+
+// SubscribeUser subscribes a client to the events addressed to its user
+func (redisClient *RedisBroadcaster) SubscribeUser(ctx context.Context, userID uuid.UUID, clientID string, eventChan chan outbound.Event) error {
+	return redisClient.subscribe(ctx, clientID, userChannel(userID), userChannel(userID), eventChan)
+}
+
+// subscribe adds a Redis channel to the client's pubsub connection; key tracks the subscription per client
+func (redisClient *RedisBroadcaster) subscribe(ctx context.Context, clientID, key, channelName string, eventChan chan outbound.Event) error {
 	redisClient.mu.Lock()
 	defer redisClient.mu.Unlock()
 
-	// Check if client is already subscribed to this auction
-	if redisClient.clientsToAuction[clientID] != nil && redisClient.clientsToAuction[clientID][auctionID.String()] {
+	// Check if client is already subscribed to this channel
+	if redisClient.clientsToAuction[clientID] != nil && redisClient.clientsToAuction[clientID][key] {
 		redisClient.logger.Info().
 			Str("client_id", clientID).
-			Str("auction_id", auctionID.String()).
-			Msg("Client already subscribed to auction")
+			Str("channel_name", channelName).
+			Msg("Client already subscribed to channel")
 		return nil
 	}
 
@@ -68,7 +103,7 @@ func (redisClient *RedisBroadcaster) Subscribe(ctx context.Context, auctionID uu
 	if redisClient.clientsToAuction[clientID] == nil {
 		redisClient.clientsToAuction[clientID] = make(map[string]bool)
 	}
-	redisClient.clientsToAuction[clientID][auctionID.String()] = true
+	redisClient.clientsToAuction[clientID][key] = true
 
 	// Get or create pubsub connection for this client
 	var pubsub *redis.PubSub
@@ -81,20 +116,21 @@ func (redisClient *RedisBroadcaster) Subscribe(ctx context.Context, auctionID uu
 		redisClient.pubsubs[clientID] = pubsub
 
 		// Start goroutine to listen for Redis messages and forward to local channel
-		go redisClient.listenForRedisMessages(pubsub, clientID, eventChan)
+		listenerCtx, cancel := context.WithCancel(redisClient.ctx)
+		clientListener := &listener{cancel: cancel, done: make(chan struct{})}
+		redisClient.listeners[clientID] = clientListener
+		go redisClient.listenForRedisMessages(listenerCtx, pubsub, clientID, eventChan, clientListener.done)
 	}
 
-	// Subscribe to the specific auction channel
-	channelName := fmt.Sprintf("auction:%s", auctionID.String())
 	if err := pubsub.Subscribe(ctx, channelName); err != nil {
-		redisClient.logger.Error().Err(err).Str("client_id", clientID).Str("auction_id", auctionID.String()).Msg("Failed to subscribe to Redis channel")
+		redisClient.logger.Error().Err(err).Str("client_id", clientID).Str("channel_name", channelName).Msg("Failed to subscribe to Redis channel")
 		return err
 	}
 
 	redisClient.logger.Info().
 		Str("client_id", clientID).
-		Str("auction_id", auctionID.String()).
-		Msg("Client subscribed to auction via Redis")
+		Str("channel_name", channelName).
+		Msg("Client subscribed to channel via Redis")
 	return nil
 }
 
@@ -108,26 +144,11 @@ func (redisClient *RedisBroadcaster) Unsubscribe(ctx context.Context, auctionID
 
 		// If no more auctions, clean up the client entry
 		if len(clientAuctions) == 0 {
-			delete(redisClient.clientsToAuction, clientID)
-
-			// Close and remove local channel
-			if eventChan, exists := redisClient.subscribers[clientID]; exists {
-				close(eventChan)
-				delete(redisClient.subscribers, clientID)
-			}
-
-			// Close Redis pubsub connection
-			if pubsub, exists := redisClient.pubsubs[clientID]; exists {
-				if err := pubsub.Close(); err != nil {
-					redisClient.logger.Error().Err(err).Str("client_id", clientID).Msg("Error closing Redis pubsub for client")
-				}
-				delete(redisClient.pubsubs, clientID)
-			}
+			redisClient.releaseClient(clientID)
 		} else {
 			// Unsubscribe from the specific auction channel
 			if pubsub, exists := redisClient.pubsubs[clientID]; exists {
-				channelName := fmt.Sprintf("auction:%s", auctionID.String())
-				if err := pubsub.Unsubscribe(ctx, channelName); err != nil {
+				if err := pubsub.Unsubscribe(ctx, auctionChannel(auctionID)); err != nil {
 					redisClient.logger.Error().Err(err).Str("client_id", clientID).Str("auction_id", auctionID.String()).Msg("Error unsubscribing from Redis channel")
 				}
 			}
@@ -141,9 +162,136 @@ func (redisClient *RedisBroadcaster) Unsubscribe(ctx context.Context, auctionID
 	return nil
 }
 
-// Publish publishes an event to all subscribers of an auction via Redis
+// UnsubscribeClient drops every subscription of a client, its user channel included, and closes
+// its Redis connection. Nothing is delivered to the client's event channel once it returns.
+func (redisClient *RedisBroadcaster) UnsubscribeClient(ctx context.Context, clientID string) error {
+	redisClient.mu.Lock()
+	defer redisClient.mu.Unlock()
+
+	redisClient.releaseClient(clientID)
+
+	redisClient.logger.Info().Str("client_id", clientID).Msg("Client unsubscribed from all channels")
+	return nil
+}
+
+// releaseClient stops forwarding to a client and closes its pubsub connection. The caller holds mu.
+func (redisClient *RedisBroadcaster) releaseClient(clientID string) {
+	delete(redisClient.clientsToAuction, clientID)
+	delete(redisClient.subscribers, clientID)
+
+	if clientListener, exists := redisClient.listeners[clientID]; exists {
+		clientListener.cancel()
+		<-clientListener.done
+		delete(redisClient.listeners, clientID)
+	}
+
+	if pubsub, exists := redisClient.pubsubs[clientID]; exists {
+		if err := pubsub.Close(); err != nil {
+			redisClient.logger.Error().Err(err).Str("client_id", clientID).Msg("Error closing Redis pubsub for client")
+		}
+		delete(redisClient.pubsubs, clientID)
+	}
+}
+
+// publishAuctionEventScript numbers an auction event, keeps it in the auction's capped history
+// and publishes it in one step, so subscribers always receive the events of an auction in
+// sequence order. The sequence number is spliced into the event JSON object as "seq".
+// KEYS[1] is the sequence counter and KEYS[2] the history stream; ARGV holds the channel,
+// the event JSON, the history length, the history TTL and the sequence TTL in seconds.
+var publishAuctionEventScript = redis.NewScript(`
+local seq = redis.call('INCR', KEYS[1])
+redis.call('EXPIRE', KEYS[1], ARGV[5])
+local payload = '{"seq":' .. seq .. ',' .. string.sub(ARGV[2], 2)
+if tonumber(ARGV[3]) > 0 then
+	redis.call('XADD', KEYS[2], 'MAXLEN', '~', ARGV[3], '0-' .. seq, 'event', payload)
+	redis.call('EXPIRE', KEYS[2], ARGV[4])
+end
+local subscribers = redis.call('PUBLISH', ARGV[1], payload)
+return {seq, subscribers}
+`)
+
+// Publish numbers an event and publishes it to all subscribers of an auction via Redis
 func (redisClient *RedisBroadcaster) Publish(ctx context.Context, auctionID uuid.UUID, event outbound.Event) error {
-	channelName := fmt.Sprintf("auction:%s", auctionID.String())
+	channelName := auctionChannel(auctionID)
+	redisClient.logger.Info().Str("channel_name", channelName).Msg("Publishing event to Redis")
+
+	if event.Timestamp == 0 {
+		event.Timestamp = time.Now().Unix()
+	}
+	event.Seq = 0
+
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		redisClient.logger.Error().Err(err).Msg("Failed to marshal event")
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	keys := []string{sequenceKey(auctionID), historyKey(auctionID)}
+	result, err := publishAuctionEventScript.Run(ctx, redisClient.client, keys,
+		channelName, eventJSON, redisClient.eventHistory, int64(eventHistoryTTL/time.Second), int64(eventSequenceTTL/time.Second)).Int64Slice()
+	if err != nil {
+		redisClient.logger.Error().Err(err).Msg("Failed to publish to Redis")
+		return fmt.Errorf("failed to publish to Redis: %w", err)
+	}
+
+	redisClient.logger.Info().
+		Str("event_type", string(event.Type)).
+		Str("channel_name", channelName).
+		Int64("seq", result[0]).
+		Int64("subscriber_count", result[1]).
+		Msg("Published event")
+
+	return nil
+}
+
+// EventsSince returns the retained events of an auction numbered after sinceSeq
+func (redisClient *RedisBroadcaster) EventsSince(ctx context.Context, auctionID uuid.UUID, sinceSeq int64) ([]outbound.Event, error) {
+	latest, err := redisClient.client.Get(ctx, sequenceKey(auctionID)).Int64()
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to read event sequence: %w", err)
+	}
+	if sinceSeq == latest {
+		return nil, nil
+	}
+	// A caller ahead of the counter cannot be caught up by replaying
+	if sinceSeq > latest {
+		return nil, shared.ErrEventsExpired
+	}
+
+	entries, err := redisClient.client.XRange(ctx, historyKey(auctionID), fmt.Sprintf("0-%d", sinceSeq+1), "+").Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event history: %w", err)
+	}
+
+	events := make([]outbound.Event, 0, len(entries))
+	for _, entry := range entries {
+		payload, ok := entry.Values["event"].(string)
+		if !ok {
+			continue
+		}
+
+		var event outbound.Event
+		if err := json.Unmarshal([]byte(payload), &event); err != nil {
+			redisClient.logger.Error().Err(err).Str("auction_id", auctionID.String()).Str("entry_id", entry.ID).Msg("Failed to unmarshal event from history")
+			continue
+		}
+		events = append(events, event)
+	}
+
+	// The oldest missed events have been trimmed from the history
+	if len(events) == 0 || events[0].Seq != sinceSeq+1 {
+		return nil, shared.ErrEventsExpired
+	}
+
+	return events, nil
+}
+
+// PublishToUser publishes an event to every connection of a single user via Redis
+func (redisClient *RedisBroadcaster) PublishToUser(ctx context.Context, userID uuid.UUID, event outbound.Event) error {
+	return redisClient.publish(ctx, userChannel(userID), event)
+}
+
+func (redisClient *RedisBroadcaster) publish(ctx context.Context, channelName string, event outbound.Event) error {
 	redisClient.logger.Info().Str("channel_name", channelName).Msg("Publishing event to Redis")
 
 	if event.Timestamp == 0 {
@@ -166,13 +314,35 @@ func (redisClient *RedisBroadcaster) Publish(ctx context.Context, auctionID uuid
 	subscriberCount := result.Val()
 	redisClient.logger.Info().
 		Str("event_type", string(event.Type)).
-		Str("auction_id", auctionID.String()).
+		Str("channel_name", channelName).
 		Int64("subscriber_count", subscriberCount).
-		Msg("Published event to auction")
+		Msg("Published event")
 
 	return nil
 }
 
+// auctionChannel is the Redis channel carrying the events of an auction
+func auctionChannel(auctionID uuid.UUID) string {
+	return fmt.Sprintf("auction:%s", auctionID.String())
+}
+
+// historyKey is the Redis stream retaining the recent events of an auction, with the
+// sequence number of each event as its entry ID ("0-<seq>")
+func historyKey(auctionID uuid.UUID) string {
+	return fmt.Sprintf("auction:%s:events", auctionID.String())
+}
+
+// sequenceKey is the counter numbering the events of an auction. It is kept for eventSequenceTTL
+// after the last event, well past the history.
+func sequenceKey(auctionID uuid.UUID) string {
+	return fmt.Sprintf("auction:%s:seq", auctionID.String())
+}
+
+// userChannel is the Redis channel carrying the events addressed to a single user
+func userChannel(userID uuid.UUID) string {
+	return fmt.Sprintf("user:%s", userID.String())
+}
+
 func (redisClient *RedisBroadcaster) GetSubscribers(ctx context.Context, auctionID uuid.UUID) ([]string, error) {
 	redisClient.mu.RLock()
 	defer redisClient.mu.RUnlock()
@@ -197,7 +367,8 @@ func (redisClient *RedisBroadcaster) GetEventChannel(clientID string) <-chan out
 }
 
 // listenForRedisMessages listens for Redis messages and forwards them to the local channel
-func (redisClient *RedisBroadcaster) listenForRedisMessages(pubsub *redis.PubSub, clientID string, localChan chan outbound.Event) {
+func (redisClient *RedisBroadcaster) listenForRedisMessages(ctx context.Context, pubsub *redis.PubSub, clientID string, localChan chan outbound.Event, done chan struct{}) {
+	defer close(done)
 	defer func() {
 		if err := recover(); err != nil {
 			redisClient.logger.Error().Interface("panic", err).Str("client_id", clientID).Msg("Redis message listener panic for client")
@@ -226,8 +397,8 @@ func (redisClient *RedisBroadcaster) listenForRedisMessages(pubsub *redis.PubSub
 				redisClient.logger.Warn().Str("client_id", clientID).Msg("Local channel full for client, dropping event")
 			}
 
-		case <-redisClient.ctx.Done():
-			redisClient.logger.Info().Str("client_id", clientID).Msg("Redis broadcaster context cancelled for client")
+		case <-ctx.Done():
+			redisClient.logger.Info().Str("client_id", clientID).Msg("Redis listener stopped for client")
 			return
 		}
 	}
@@ -235,21 +406,14 @@ func (redisClient *RedisBroadcaster) listenForRedisMessages(pubsub *redis.PubSub
 
 func (redisClient *RedisBroadcaster) Close() error {
 	redisClient.cancel()
+	redisClient.stopWatches()
 
 	redisClient.mu.Lock()
 	defer redisClient.mu.Unlock()
 
-	for clientID, eventChan := range redisClient.subscribers {
-		close(eventChan)
-		delete(redisClient.subscribers, clientID)
-	}
-
-	// Close all pubsub connections
-	for clientID, pubsub := range redisClient.pubsubs {
-		if err := pubsub.Close(); err != nil {
-			redisClient.logger.Error().Err(err).Str("client_id", clientID).Msg("Error closing Redis pubsub for client")
-		}
-		delete(redisClient.pubsubs, clientID)
+	// Close all pubsub connections; the local channels are left to their owners
+	for clientID := range redisClient.pubsubs {
+		redisClient.releaseClient(clientID)
 	}
 
 	return redisClient.client.Close()
@@ -0,0 +1,122 @@
+package broadcaster
+
+import (
+	"context"
+	"sync"
+
+	"troffee-auction-service/internal/ports/outbound"
+
+	"github.com/google/uuid"
+)
+
+// auctionWatch shares one Redis subscription of an auction between its read-only watchers.
+// Events arrive on events and are copied to every watcher's channel.
+type auctionWatch struct {
+	clientID string
+	events   chan outbound.Event
+	mu       sync.Mutex
+	watchers map[chan outbound.Event]struct{}
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// watchBuffer is how many events of a watched auction may wait for the fan-out
+const watchBuffer = 100
+
+// Watch delivers the events of an auction to a read-only watcher. The first watcher of an
+// auction subscribes to it in Redis; later ones join that subscription.
+func (redisClient *RedisBroadcaster) Watch(ctx context.Context, auctionID uuid.UUID, eventChan chan outbound.Event) error {
+	redisClient.watchMu.Lock()
+	defer redisClient.watchMu.Unlock()
+
+	watch, exists := redisClient.watches[auctionID]
+	if !exists {
+		watch = &auctionWatch{
+			clientID: "watch-" + auctionID.String(),
+			events:   make(chan outbound.Event, watchBuffer),
+			watchers: make(map[chan outbound.Event]struct{}),
+			stop:     make(chan struct{}),
+			done:     make(chan struct{}),
+		}
+		if err := redisClient.Subscribe(ctx, auctionID, watch.clientID, watch.events); err != nil {
+			// A failed subscription is still registered with the broadcaster
+			redisClient.UnsubscribeClient(context.Background(), watch.clientID)
+			return err
+		}
+		redisClient.watches[auctionID] = watch
+		go redisClient.fanOut(auctionID, watch)
+	}
+
+	watch.mu.Lock()
+	watch.watchers[eventChan] = struct{}{}
+	watchers := len(watch.watchers)
+	watch.mu.Unlock()
+
+	redisClient.logger.Debug().Str("auction_id", auctionID.String()).Int("watchers", watchers).Msg("Watcher joined auction")
+	return nil
+}
+
+// Unwatch stops delivering an auction's events to a watcher. The last watcher to leave drops
+// the Redis subscription.
+func (redisClient *RedisBroadcaster) Unwatch(ctx context.Context, auctionID uuid.UUID, eventChan chan outbound.Event) error {
+	redisClient.watchMu.Lock()
+	defer redisClient.watchMu.Unlock()
+
+	watch, exists := redisClient.watches[auctionID]
+	if !exists {
+		return nil
+	}
+
+	watch.mu.Lock()
+	delete(watch.watchers, eventChan)
+	watchers := len(watch.watchers)
+	watch.mu.Unlock()
+
+	redisClient.logger.Debug().Str("auction_id", auctionID.String()).Int("watchers", watchers).Msg("Watcher left auction")
+	if watchers > 0 {
+		return nil
+	}
+
+	delete(redisClient.watches, auctionID)
+	err := redisClient.UnsubscribeClient(ctx, watch.clientID)
+	close(watch.stop)
+	<-watch.done
+	return err
+}
+
+// stopWatches ends the fan-out of every watched auction; their subscriptions are released with
+// the other clients on Close
+func (redisClient *RedisBroadcaster) stopWatches() {
+	redisClient.watchMu.Lock()
+	defer redisClient.watchMu.Unlock()
+
+	for auctionID, watch := range redisClient.watches {
+		close(watch.stop)
+		<-watch.done
+		delete(redisClient.watches, auctionID)
+	}
+}
+
+// fanOut copies the events of a watched auction to each of its watchers. A watcher whose channel
+// is full misses the event rather than holding up the others.
+func (redisClient *RedisBroadcaster) fanOut(auctionID uuid.UUID, watch *auctionWatch) {
+	defer close(watch.done)
+
+	for {
+		select {
+		case event := <-watch.events:
+			watch.mu.Lock()
+			for eventChan := range watch.watchers {
+				select {
+				case eventChan <- event:
+				default:
+					redisClient.logger.Warn().Str("auction_id", auctionID.String()).Msg("Watcher channel full, dropping event")
+				}
+			}
+			watch.mu.Unlock()
+
+		case <-watch.stop:
+			return
+		}
+	}
+}
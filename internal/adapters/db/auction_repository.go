@@ -3,14 +3,124 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"troffee-auction-service/internal/domain/auction"
+	"troffee-auction-service/internal/domain/bid"
 	"troffee-auction-service/internal/domain/shared"
 
 	"github.com/google/uuid"
 )
 
+// auctionColumns lists the auction columns in the order scanAuction expects
+const auctionColumns = `id, item_id, creator_id, start_time, end_time, starting_price, current_price, status, type,
+		soft_close_seconds, reserve_price, buy_now_price, price_drop_amount, price_drop_interval_seconds, floor_price,
+		increment_rule, quantity, pricing, relist_policy, relisted_from, relist_count, currency, created_at, updated_at`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanAuction scans a row selected with auctionColumns into an auction
+func scanAuction(row rowScanner) (*auction.Auction, error) {
+	var auction auction.Auction
+	var incrementRule, relistPolicy []byte
+	err := row.Scan(
+		&auction.ID,
+		&auction.ItemID,
+		&auction.CreatorID,
+		&auction.StartTime,
+		&auction.EndTime,
+		&auction.StartingPrice,
+		&auction.CurrentPrice,
+		&auction.Status,
+		&auction.Type,
+		&auction.SoftCloseSeconds,
+		&auction.ReservePrice,
+		&auction.BuyNowPrice,
+		&auction.PriceDropAmount,
+		&auction.PriceDropIntervalSeconds,
+		&auction.FloorPrice,
+		&incrementRule,
+		&auction.Quantity,
+		&auction.Pricing,
+		&relistPolicy,
+		&auction.RelistedFrom,
+		&auction.RelistCount,
+		&auction.Currency,
+		&auction.CreatedAt,
+		&auction.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if auction.IncrementRule, err = unmarshalIncrementRule(incrementRule); err != nil {
+		return nil, err
+	}
+	if auction.RelistPolicy, err = unmarshalRelistPolicy(relistPolicy); err != nil {
+		return nil, err
+	}
+	auction.SetCurrency(auction.Currency)
+
+	return &auction, nil
+}
+
+// marshalIncrementRule encodes an increment rule for the JSONB column, NULL when unset
+func marshalIncrementRule(rule *auction.IncrementRule) (interface{}, error) {
+	if rule == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(rule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode increment rule: %w", err)
+	}
+	return data, nil
+}
+
+// unmarshalIncrementRule decodes the JSONB increment_rule column
+func unmarshalIncrementRule(data []byte) (*auction.IncrementRule, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var rule auction.IncrementRule
+	if err := json.Unmarshal(data, &rule); err != nil {
+		return nil, fmt.Errorf("failed to decode increment rule: %w", err)
+	}
+	return &rule, nil
+}
+
+// marshalRelistPolicy encodes a relist policy for the JSONB column, NULL when unset
+func marshalRelistPolicy(policy *auction.RelistPolicy) (interface{}, error) {
+	if policy == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode relist policy: %w", err)
+	}
+	return data, nil
+}
+
+// unmarshalRelistPolicy decodes the JSONB relist_policy column
+func unmarshalRelistPolicy(data []byte) (*auction.RelistPolicy, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var policy auction.RelistPolicy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to decode relist policy: %w", err)
+	}
+	return &policy, nil
+}
+
 // AuctionRepository implements the auction repository interface
 type AuctionRepository struct {
 	conn *Connection
@@ -24,11 +134,23 @@ func NewAuctionRepository(conn *Connection) *AuctionRepository {
 // Create creates a new auction
 func (r *AuctionRepository) Create(ctx context.Context, auction *auction.Auction) error {
 	query := `
-		INSERT INTO auctions (id, item_id, creator_id, start_time, end_time, starting_price, current_price, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO auctions (id, item_id, creator_id, start_time, end_time, starting_price, current_price, status, type,
+		                      soft_close_seconds, reserve_price, buy_now_price,
+		                      price_drop_amount, price_drop_interval_seconds, floor_price, increment_rule,
+		                      quantity, pricing, relist_policy, relisted_from, relist_count, currency, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24)
 	`
 
-	_, err := r.conn.GetDB().ExecContext(ctx, query,
+	incrementRule, err := marshalIncrementRule(auction.IncrementRule)
+	if err != nil {
+		return err
+	}
+	relistPolicy, err := marshalRelistPolicy(auction.RelistPolicy)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.conn.GetDB().ExecContext(ctx, query,
 		auction.ID,
 		auction.ItemID,
 		auction.CreatorID,
@@ -37,6 +159,20 @@ func (r *AuctionRepository) Create(ctx context.Context, auction *auction.Auction
 		auction.StartingPrice,
 		auction.CurrentPrice,
 		auction.Status,
+		auction.Type,
+		auction.SoftCloseSeconds,
+		auction.ReservePrice,
+		auction.BuyNowPrice,
+		auction.PriceDropAmount,
+		auction.PriceDropIntervalSeconds,
+		auction.FloorPrice,
+		incrementRule,
+		auction.Quantity,
+		auction.Pricing,
+		relistPolicy,
+		auction.RelistedFrom,
+		auction.RelistCount,
+		auction.Currency,
 		auction.CreatedAt,
 		auction.UpdatedAt,
 	)
@@ -51,25 +187,12 @@ func (r *AuctionRepository) Create(ctx context.Context, auction *auction.Auction
 // GetByID retrieves an auction by ID
 func (r *AuctionRepository) GetByID(ctx context.Context, id uuid.UUID) (*auction.Auction, error) {
 	query := `
-		SELECT id, item_id, creator_id, start_time, end_time, starting_price, current_price, status, created_at, updated_at
+		SELECT ` + auctionColumns + `
 		FROM auctions
 		WHERE id = $1
 	`
 
-	var auction auction.Auction
-	err := r.conn.GetDB().QueryRowContext(ctx, query, id).Scan(
-		&auction.ID,
-		&auction.ItemID,
-		&auction.CreatorID,
-		&auction.StartTime,
-		&auction.EndTime,
-		&auction.StartingPrice,
-		&auction.CurrentPrice,
-		&auction.Status,
-		&auction.CreatedAt,
-		&auction.UpdatedAt,
-	)
-
+	auction, err := scanAuction(r.conn.GetDB().QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, shared.ErrAuctionNotFound
@@ -77,13 +200,13 @@ func (r *AuctionRepository) GetByID(ctx context.Context, id uuid.UUID) (*auction
 		return nil, fmt.Errorf("failed to get auction: %w", err)
 	}
 
-	return &auction, nil
+	return auction, nil
 }
 
 // List retrieves a list of auctions with optional filters
 func (r *AuctionRepository) List(ctx context.Context, status *auction.Status, page, pageSize int) ([]*auction.Auction, error) {
 	baseQuery := `
-		SELECT id, item_id, creator_id, start_time, end_time, starting_price, current_price, status, created_at, updated_at
+		SELECT ` + auctionColumns + `
 		FROM auctions
 	`
 
@@ -112,23 +235,11 @@ func (r *AuctionRepository) List(ctx context.Context, status *auction.Status, pa
 
 	var auctions []*auction.Auction
 	for rows.Next() {
-		var auction auction.Auction
-		err := rows.Scan(
-			&auction.ID,
-			&auction.ItemID,
-			&auction.CreatorID,
-			&auction.StartTime,
-			&auction.EndTime,
-			&auction.StartingPrice,
-			&auction.CurrentPrice,
-			&auction.Status,
-			&auction.CreatedAt,
-			&auction.UpdatedAt,
-		)
+		auction, err := scanAuction(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan auction: %w", err)
 		}
-		auctions = append(auctions, &auction)
+		auctions = append(auctions, auction)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -138,12 +249,12 @@ func (r *AuctionRepository) List(ctx context.Context, status *auction.Status, pa
 	return auctions, nil
 }
 
-// GetActiveByItemID retrieves active auctions for a specific item
+// GetActiveByItemID retrieves active and upcoming (pending) auctions for a specific item
 func (r *AuctionRepository) GetActiveByItemID(ctx context.Context, itemID uuid.UUID) ([]*auction.Auction, error) {
 	query := `
-		SELECT id, item_id, creator_id, start_time, end_time, starting_price, current_price, status, created_at, updated_at
+		SELECT ` + auctionColumns + `
 		FROM auctions
-		WHERE item_id = $1 AND status = 'active'
+		WHERE item_id = $1 AND status IN ('pending', 'active')
 		ORDER BY created_at DESC
 	`
 
@@ -155,23 +266,11 @@ func (r *AuctionRepository) GetActiveByItemID(ctx context.Context, itemID uuid.U
 
 	var auctions []*auction.Auction
 	for rows.Next() {
-		var auction auction.Auction
-		err := rows.Scan(
-			&auction.ID,
-			&auction.ItemID,
-			&auction.CreatorID,
-			&auction.StartTime,
-			&auction.EndTime,
-			&auction.StartingPrice,
-			&auction.CurrentPrice,
-			&auction.Status,
-			&auction.CreatedAt,
-			&auction.UpdatedAt,
-		)
+		auction, err := scanAuction(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan auction: %w", err)
 		}
-		auctions = append(auctions, &auction)
+		auctions = append(auctions, auction)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -186,10 +285,18 @@ func (r *AuctionRepository) Update(ctx context.Context, auction *auction.Auction
 	query := `
 		UPDATE auctions
 		SET item_id = $2, creator_id = $3, start_time = $4, end_time = $5, 
-		    starting_price = $6, current_price = $7, status = $8, updated_at = $9
+		    starting_price = $6, current_price = $7, status = $8, type = $9, soft_close_seconds = $10,
+		    reserve_price = $11, buy_now_price = $12, price_drop_amount = $13,
+		    price_drop_interval_seconds = $14, floor_price = $15, increment_rule = $16,
+		    quantity = $17, pricing = $18, updated_at = $19
 		WHERE id = $1
 	`
 
+	incrementRule, err := marshalIncrementRule(auction.IncrementRule)
+	if err != nil {
+		return err
+	}
+
 	result, err := r.conn.GetDB().ExecContext(ctx, query,
 		auction.ID,
 		auction.ItemID,
@@ -199,6 +306,16 @@ func (r *AuctionRepository) Update(ctx context.Context, auction *auction.Auction
 		auction.StartingPrice,
 		auction.CurrentPrice,
 		auction.Status,
+		auction.Type,
+		auction.SoftCloseSeconds,
+		auction.ReservePrice,
+		auction.BuyNowPrice,
+		auction.PriceDropAmount,
+		auction.PriceDropIntervalSeconds,
+		auction.FloorPrice,
+		incrementRule,
+		auction.Quantity,
+		auction.Pricing,
 		auction.UpdatedAt,
 	)
 
@@ -218,6 +335,227 @@ func (r *AuctionRepository) Update(ctx context.Context, auction *auction.Auction
 	return nil
 }
 
+// UpdateStatusWithOCC moves an auction to a new status only if it still has the expected one
+func (r *AuctionRepository) UpdateStatusWithOCC(ctx context.Context, auctionID uuid.UUID, expected, status auction.Status) error {
+	query := `
+		UPDATE auctions
+		SET status = $2, updated_at = $3
+		WHERE id = $1 AND status = $4
+	`
+
+	result, err := r.conn.GetDB().ExecContext(ctx, query, auctionID, status, time.Now(), expected)
+	if err != nil {
+		return fmt.Errorf("failed to update auction status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return shared.ErrAuctionStatusChanged
+	}
+
+	return nil
+}
+
+// CancelWithOCC cancels an auction only if it still has the expected status. Its outstanding bids are
+// rejected and its proxy bids dropped in the same transaction, so no bid can slip in between.
+// Without a reason, an auction that received bids is left untouched. Returns the number of rejected bids.
+func (r *AuctionRepository) CancelWithOCC(ctx context.Context, auctionID uuid.UUID, expected auction.Status, reasonGiven bool) (int, error) {
+	var rejected int
+	err := r.conn.ExecuteTransaction(func(tx *sql.Tx) error {
+		// Bids are placed only while the auction is active, so none can follow the status flip
+		result, err := tx.ExecContext(ctx, `
+			UPDATE auctions
+			SET status = $2, updated_at = $3
+			WHERE id = $1 AND status = $4
+		`, auctionID, auction.StatusCancelled, time.Now(), expected)
+		if err != nil {
+			return fmt.Errorf("failed to cancel auction: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rowsAffected == 0 {
+			return shared.ErrAuctionStatusChanged
+		}
+
+		if !reasonGiven {
+			var hasBids bool
+			if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM bids WHERE auction_id = $1)`, auctionID).Scan(&hasBids); err != nil {
+				return fmt.Errorf("failed to check bids of auction: %w", err)
+			}
+			if hasBids {
+				return shared.ErrCancelReasonRequired
+			}
+		}
+
+		result, err = tx.ExecContext(ctx, `
+			UPDATE bids
+			SET status = 'rejected', updated_at = $2
+			WHERE auction_id = $1 AND status IN ('pending', 'accepted')
+		`, auctionID, time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to reject bids of cancelled auction: %w", err)
+		}
+		rowsAffected, err = result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		rejected = int(rowsAffected)
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM proxy_bids WHERE auction_id = $1`, auctionID); err != nil {
+			return fmt.Errorf("failed to drop proxy bids of cancelled auction: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return rejected, nil
+}
+
+// EndWithOCC ends an active auction whose end time has passed by now. The auction, already marked
+// ended, and all of its bids are handed to resolve in the same transaction; the final price resolve
+// sets on the auction is saved with it. Bids lock the auction row, so none can land once it is ended.
+func (r *AuctionRepository) EndWithOCC(ctx context.Context, auctionID uuid.UUID, now time.Time, resolve func(a *auction.Auction, bids []*bid.Bid) *shared.AuctionEndResult) (*shared.AuctionEndResult, error) {
+	var result *shared.AuctionEndResult
+	err := r.conn.ExecuteTransaction(func(tx *sql.Tx) error {
+		endQuery := `
+			UPDATE auctions
+			SET status = $2, updated_at = $3
+			WHERE id = $1 AND status = $4 AND end_time <= $3
+			RETURNING ` + auctionColumns
+
+		ended, err := scanAuction(tx.QueryRowContext(ctx, endQuery, auctionID, auction.StatusEnded, now, auction.StatusActive))
+		if err == sql.ErrNoRows {
+			return notEndableTx(ctx, tx, auctionID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to end auction: %w", err)
+		}
+
+		bidsQuery := `
+			SELECT ` + bidColumns + `
+			FROM bids
+			WHERE auction_id = $1
+			ORDER BY amount DESC, created_at ASC
+		`
+
+		rows, err := tx.QueryContext(ctx, bidsQuery, auctionID)
+		if err != nil {
+			return fmt.Errorf("failed to get bids: %w", err)
+		}
+		bids, err := scanBids(rows)
+		if err != nil {
+			return err
+		}
+
+		previousPrice := ended.CurrentPrice
+		result = resolve(ended, bids)
+		if ended.CurrentPrice.Equal(previousPrice) {
+			return nil
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE auctions SET current_price = $2 WHERE id = $1`, auctionID, ended.CurrentPrice); err != nil {
+			return fmt.Errorf("failed to save final price: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// notEndableTx explains why EndWithOCC did not end an auction
+func notEndableTx(ctx context.Context, tx *sql.Tx, auctionID uuid.UUID) error {
+	var status auction.Status
+	err := tx.QueryRowContext(ctx, `SELECT status FROM auctions WHERE id = $1`, auctionID).Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return shared.ErrAuctionNotFound
+		}
+		return fmt.Errorf("failed to get auction: %w", err)
+	}
+
+	switch {
+	case status == auction.StatusEnded:
+		return shared.ErrAuctionAlreadyEnded
+	case status != auction.StatusActive:
+		return shared.ErrAuctionStatusChanged
+	default:
+		// Still active, so a late bid pushed the end time forward
+		return shared.ErrAuctionNotEnded
+	}
+}
+
+// UpdatePriceWithOCC sets the current price of an active auction only if it still has the expected price
+func (r *AuctionRepository) UpdatePriceWithOCC(ctx context.Context, auctionID uuid.UUID, expectedCurrentPrice, newPrice shared.Money) error {
+	query := `
+		UPDATE auctions
+		SET current_price = $2, updated_at = $3
+		WHERE id = $1 AND current_price = $4 AND status = 'active'
+	`
+
+	result, err := r.conn.GetDB().ExecContext(ctx, query, auctionID, newPrice, time.Now(), expectedCurrentPrice)
+	if err != nil {
+		return fmt.Errorf("failed to update auction price: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return shared.ErrAuctionPriceChanged
+	}
+
+	return nil
+}
+
+// UpdateTermsWithOCC saves edited starting price, current price and end time of an open
+// auction only if it still has the expected price and no bids
+func (r *AuctionRepository) UpdateTermsWithOCC(ctx context.Context, auction *auction.Auction, expectedCurrentPrice shared.Money) error {
+	query := `
+		UPDATE auctions
+		SET starting_price = $2, current_price = $3, end_time = $4, updated_at = $5
+		WHERE id = $1 AND current_price = $6 AND status IN ('pending', 'active')
+			AND NOT EXISTS (SELECT 1 FROM bids WHERE bids.auction_id = auctions.id)
+	`
+
+	result, err := r.conn.GetDB().ExecContext(ctx, query,
+		auction.ID,
+		auction.StartingPrice,
+		auction.CurrentPrice,
+		auction.EndTime,
+		auction.UpdatedAt,
+		expectedCurrentPrice,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update auction terms: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return shared.ErrAuctionPriceChanged
+	}
+
+	return nil
+}
+
 // Delete deletes an auction
 func (r *AuctionRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM auctions WHERE id = $1`
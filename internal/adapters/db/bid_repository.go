@@ -6,26 +6,72 @@ import (
 	"fmt"
 	"time"
 
+	"troffee-auction-service/internal/domain/auction"
 	"troffee-auction-service/internal/domain/bid"
 	"troffee-auction-service/internal/domain/shared"
+	"troffee-auction-service/internal/ports/outbound"
 
 	"github.com/google/uuid"
 )
 
+// bidColumns lists the bid columns in the order scanBid expects
+const bidColumns = `id, auction_id, user_id, amount, quantity, status, created_at, updated_at`
+
+// scanBid scans a row selected with bidColumns into a bid
+func scanBid(row rowScanner) (*bid.Bid, error) {
+	var bid bid.Bid
+	err := row.Scan(
+		&bid.ID,
+		&bid.AuctionID,
+		&bid.UserID,
+		&bid.Amount,
+		&bid.Quantity,
+		&bid.Status,
+		&bid.CreatedAt,
+		&bid.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bid, nil
+}
+
+// scanBids scans all rows selected with bidColumns
+func scanBids(rows *sql.Rows) ([]*bid.Bid, error) {
+	defer rows.Close()
+
+	var bids []*bid.Bid
+	for rows.Next() {
+		bid, err := scanBid(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bid: %w", err)
+		}
+		bids = append(bids, bid)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating bids: %w", err)
+	}
+
+	return bids, nil
+}
+
 // BidRepository implements the bid repository interface
 type BidRepository struct {
-	conn *Connection
+	conn    *Connection
+	fxRates outbound.FXRateProvider // converts open exposure into the currency of credit limits
 }
 
 // NewBidRepository creates a new bid repository
-func NewBidRepository(conn *Connection) *BidRepository {
-	return &BidRepository{conn: conn}
+func NewBidRepository(conn *Connection, fxRates outbound.FXRateProvider) *BidRepository {
+	return &BidRepository{conn: conn, fxRates: fxRates}
 }
 
 func (r *BidRepository) Create(ctx context.Context, bid *bid.Bid) error {
 	query := `
-		INSERT INTO bids (id, auction_id, user_id, amount, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO bids (id, auction_id, user_id, amount, quantity, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`
 
 	_, err := r.conn.GetDB().ExecContext(ctx, query,
@@ -33,6 +79,7 @@ func (r *BidRepository) Create(ctx context.Context, bid *bid.Bid) error {
 		bid.AuctionID,
 		bid.UserID,
 		bid.Amount,
+		bid.Quantity,
 		bid.Status,
 		bid.CreatedAt,
 		bid.UpdatedAt,
@@ -47,36 +94,26 @@ func (r *BidRepository) Create(ctx context.Context, bid *bid.Bid) error {
 
 func (r *BidRepository) GetByID(ctx context.Context, id uuid.UUID) (*bid.Bid, error) {
 	query := `
-		SELECT id, auction_id, user_id, amount, status, created_at, updated_at
+		SELECT ` + bidColumns + `
 		FROM bids
 		WHERE id = $1
 	`
 
-	var bid bid.Bid
-	err := r.conn.GetDB().QueryRowContext(ctx, query, id).Scan(
-		&bid.ID,
-		&bid.AuctionID,
-		&bid.UserID,
-		&bid.Amount,
-		&bid.Status,
-		&bid.CreatedAt,
-		&bid.UpdatedAt,
-	)
-
+	bid, err := scanBid(r.conn.GetDB().QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("bid not found")
+			return nil, shared.ErrBidNotFound
 		}
 		return nil, fmt.Errorf("failed to get bid: %w", err)
 	}
 
-	return &bid, nil
+	return bid, nil
 }
 
 // GetByAuctionID retrieves all bids for an auction
 func (r *BidRepository) GetByAuctionID(ctx context.Context, auctionID uuid.UUID) ([]*bid.Bid, error) {
 	query := `
-		SELECT id, auction_id, user_id, amount, status, created_at, updated_at
+		SELECT ` + bidColumns + `
 		FROM bids
 		WHERE auction_id = $1
 		ORDER BY amount DESC, created_at ASC
@@ -86,62 +123,34 @@ func (r *BidRepository) GetByAuctionID(ctx context.Context, auctionID uuid.UUID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get bids: %w", err)
 	}
-	defer rows.Close()
 
-	var bids []*bid.Bid
-	for rows.Next() {
-		var bid bid.Bid
-		err := rows.Scan(
-			&bid.ID,
-			&bid.AuctionID,
-			&bid.UserID,
-			&bid.Amount,
-			&bid.Status,
-			&bid.CreatedAt,
-			&bid.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan bid: %w", err)
-		}
-		bids = append(bids, &bid)
-	}
+	return scanBids(rows)
+}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating bids: %w", err)
+// GetBestBid retrieves the winning bid for an auction, the earliest one on ties
+func (r *BidRepository) GetBestBid(ctx context.Context, auctionID uuid.UUID, lowestWins bool) (*bid.Bid, error) {
+	order := "DESC"
+	if lowestWins {
+		order = "ASC"
 	}
 
-	return bids, nil
-}
-
-// GetHighestBid retrieves the highest bid for an auction
-func (r *BidRepository) GetHighestBid(ctx context.Context, auctionID uuid.UUID) (*bid.Bid, error) {
 	query := `
-		SELECT id, auction_id, user_id, amount, status, created_at, updated_at
+		SELECT ` + bidColumns + `
 		FROM bids
 		WHERE auction_id = $1 AND status = 'accepted'
-		ORDER BY amount DESC, created_at ASC
+		ORDER BY amount ` + order + `, created_at ASC
 		LIMIT 1
 	`
 
-	var bid bid.Bid
-	err := r.conn.GetDB().QueryRowContext(ctx, query, auctionID).Scan(
-		&bid.ID,
-		&bid.AuctionID,
-		&bid.UserID,
-		&bid.Amount,
-		&bid.Status,
-		&bid.CreatedAt,
-		&bid.UpdatedAt,
-	)
-
+	bid, err := scanBid(r.conn.GetDB().QueryRowContext(ctx, query, auctionID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, shared.ErrNoBidsFound
 		}
-		return nil, fmt.Errorf("failed to get highest bid: %w", err)
+		return nil, fmt.Errorf("failed to get best bid: %w", err)
 	}
 
-	return &bid, nil
+	return bid, nil
 }
 
 // Update updates a bid
@@ -171,7 +180,7 @@ func (r *BidRepository) Update(ctx context.Context, bid *bid.Bid) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("bid not found")
+		return shared.ErrBidNotFound
 	}
 
 	return nil
@@ -183,20 +192,30 @@ PlaceBidWithOCC places a bid using optimistic concurrency control.
  2. Validating the expected price matches the actual price
  3. Updating the auction only if the price hasn't changed
  4. Failing if another transaction modified the auction concurrently
+ 5. Letting competing proxy bids respond within the same transaction
+
+The bidder's credit limit is checked in the same transaction, see checkCreditTx.
+Reverse auctions invert the ordering: the new bid must be lower than the current price.
+The bid must also beat the current price by the auction's increment.
 */
-func (r *BidRepository) PlaceBidWithOCC(ctx context.Context, newBid *bid.Bid, expectedCurrentPrice float64) error {
-	return r.conn.ExecuteTransaction(func(tx *sql.Tx) error {
+func (r *BidRepository) PlaceBidWithOCC(ctx context.Context, newBid *bid.Bid, expectedCurrentPrice shared.Money) ([]*bid.Bid, *auction.Extension, error) {
+	var proxyBids []*bid.Bid
+	var extension *auction.Extension
+
+	err := r.conn.ExecuteTransaction(func(tx *sql.Tx) error {
 		// First, check if the auction is still active
 		auctionQuery := `
-			SELECT current_price, status, updated_at
+			SELECT current_price, status, type, increment_rule, end_time, updated_at
 			FROM auctions
 			WHERE id = $1
 		`
 
-		var dbCurrentPrice float64
+		var dbCurrentPrice shared.Money
 		var status string
-		var updatedAt time.Time
-		err := tx.QueryRowContext(ctx, auctionQuery, newBid.AuctionID).Scan(&dbCurrentPrice, &status, &updatedAt)
+		var auctionType auction.Type
+		var incrementRule []byte
+		var endTime, updatedAt time.Time
+		err := tx.QueryRowContext(ctx, auctionQuery, newBid.AuctionID).Scan(&dbCurrentPrice, &status, &auctionType, &incrementRule, &endTime, &updatedAt)
 		if err != nil {
 			if err == sql.ErrNoRows {
 				return shared.ErrAuctionNotFound
@@ -204,42 +223,50 @@ func (r *BidRepository) PlaceBidWithOCC(ctx context.Context, newBid *bid.Bid, ex
 			return fmt.Errorf("failed to get auction for OCC: %w", err)
 		}
 
-		if status != "active" {
+		if !acceptsBidsAt(status, endTime, newBid.CreatedAt) {
 			return shared.ErrAuctionNotAcceptingBids
 		}
 
-		if dbCurrentPrice != expectedCurrentPrice {
-			return shared.ErrBidAmountTooLow
+		outbidErr := shared.ErrBidAmountTooLow
+		if auctionType.LowestBidWins() {
+			outbidErr = shared.ErrBidAmountTooHigh
 		}
 
-		if newBid.Amount <= dbCurrentPrice {
-			return shared.ErrBidAmountTooLow
+		if !dbCurrentPrice.Equal(expectedCurrentPrice) {
+			return outbidErr
 		}
 
-		// Insert the new bid
-		bidQuery := `
-			INSERT INTO bids (id, auction_id, user_id, amount, status, created_at, updated_at)
-			VALUES ($1, $2, $3, $4, $5, $6, $7)
-		`
+		if !auctionType.Outbids(newBid.Amount, dbCurrentPrice) {
+			return outbidErr
+		}
 
-		_, err = tx.ExecContext(ctx, bidQuery,
-			newBid.ID,
-			newBid.AuctionID,
-			newBid.UserID,
-			newBid.Amount,
-			newBid.Status,
-			newBid.CreatedAt,
-			newBid.UpdatedAt,
-		)
+		rule, err := unmarshalIncrementRule(incrementRule)
 		if err != nil {
-			return fmt.Errorf("failed to insert bid: %w", err)
+			return err
+		}
+		current := &auction.Auction{Type: auctionType, CurrentPrice: dbCurrentPrice, IncrementRule: rule}
+		if !current.MeetsIncrement(newBid.Amount) {
+			return shared.NewNextBidError(current.NextBid(), current.LowestBidWins())
 		}
 
-		// Use the expected current price in the WHERE clause to ensure no other transaction modified it
+		// Suppliers in reverse auctions are paid, so their bids do not use credit
+		if !auctionType.LowestBidWins() {
+			if err := r.checkCreditTx(ctx, tx, newBid.UserID, newBid.AuctionID, newBid.Amount.Mul(newBid.Quantity)); err != nil {
+				return err
+			}
+		}
+
+		// Insert the new bid
+		if err := r.insertBidTx(ctx, tx, newBid); err != nil {
+			return err
+		}
+
+		// Use the expected current price in the WHERE clause to ensure no other transaction modified it,
+		// and the status so that a bid racing the close cannot land on an ended auction
 		updateQuery := `
 			UPDATE auctions
 			SET current_price = $2, updated_at = $3
-			WHERE id = $1 AND current_price = $4
+			WHERE id = $1 AND current_price = $4 AND status = 'active'
 		`
 
 		result, err := tx.ExecContext(ctx, updateQuery,
@@ -258,11 +285,741 @@ func (r *BidRepository) PlaceBidWithOCC(ctx context.Context, newBid *bid.Bid, ex
 			return fmt.Errorf("failed to get rows affected: %w", err)
 		}
 
-		// If no rows were affected, it means another transaction modified the auction
+		// If no rows were affected, another bid or the auction's close got there first
 		if rowsAffected == 0 {
+			return outbidErr
+		}
+
+		// The auction row is now locked, so the end time can be extended safely
+		extension, err = r.extendForLateBidTx(ctx, tx, newBid.AuctionID, newBid.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		// Proxy bidding only exists for English auctions
+		if auctionType != auction.TypeEnglish {
+			return nil
+		}
+
+		// The auction row is now locked, so proxies can safely respond to the new bid
+		proxyBids, err = r.resolveProxyBidsTx(ctx, tx, newBid.AuctionID, newBid.Amount, &newBid.UserID, rule.IncrementAt, time.Now())
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return proxyBids, extension, nil
+}
+
+/*
+PlaceMaxBidWithOCC stores a proxy bid and resolves it against competing proxies.
+ 1. Locking the auction row so proxies are resolved one at a time
+ 2. Validating the expected price matches the actual price
+ 3. Storing the maximum, which may only ever be raised
+ 4. Inserting the visible bids produced by the resolution
+*/
+func (r *BidRepository) PlaceMaxBidWithOCC(ctx context.Context, proxy *bid.ProxyBid, expectedCurrentPrice shared.Money) ([]*bid.Bid, *auction.Extension, error) {
+	var placedBids []*bid.Bid
+	var extension *auction.Extension
+
+	err := r.conn.ExecuteTransaction(func(tx *sql.Tx) error {
+		auctionQuery := `
+			SELECT current_price, status, increment_rule, end_time
+			FROM auctions
+			WHERE id = $1
+			FOR UPDATE
+		`
+
+		var dbCurrentPrice shared.Money
+		var status string
+		var incrementRule []byte
+		var endTime time.Time
+		err := tx.QueryRowContext(ctx, auctionQuery, proxy.AuctionID).Scan(&dbCurrentPrice, &status, &incrementRule, &endTime)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return shared.ErrAuctionNotFound
+			}
+			return fmt.Errorf("failed to get auction for OCC: %w", err)
+		}
+
+		if !acceptsBidsAt(status, endTime, proxy.UpdatedAt) {
+			return shared.ErrAuctionNotAcceptingBids
+		}
+
+		if !dbCurrentPrice.Equal(expectedCurrentPrice) {
+			return shared.ErrBidAmountTooLow
+		}
+
+		if !proxy.MaxAmount.GreaterThan(dbCurrentPrice) {
 			return shared.ErrBidAmountTooLow
 		}
 
+		// The proxy may bid up to its maximum, so the whole maximum has to fit the credit limit
+		if err := r.checkCreditTx(ctx, tx, proxy.UserID, proxy.AuctionID, proxy.MaxAmount); err != nil {
+			return err
+		}
+
+		rule, err := unmarshalIncrementRule(incrementRule)
+		if err != nil {
+			return err
+		}
+
+		// Store the maximum, only replacing an existing one if it is being raised
+		proxyQuery := `
+			INSERT INTO proxy_bids (id, auction_id, user_id, max_amount, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (auction_id, user_id) DO UPDATE
+			SET max_amount = EXCLUDED.max_amount, updated_at = EXCLUDED.updated_at
+			WHERE proxy_bids.max_amount < EXCLUDED.max_amount
+			RETURNING id, created_at
+		`
+
+		err = tx.QueryRowContext(ctx, proxyQuery,
+			proxy.ID,
+			proxy.AuctionID,
+			proxy.UserID,
+			proxy.MaxAmount,
+			proxy.CreatedAt,
+			proxy.UpdatedAt,
+		).Scan(&proxy.ID, &proxy.CreatedAt)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return shared.ErrMaxBidNotIncreased
+			}
+			return fmt.Errorf("failed to store proxy bid: %w", err)
+		}
+
+		leaderID, err := r.getLeaderTx(ctx, tx, proxy.AuctionID)
+		if err != nil {
+			return err
+		}
+
+		placedBids, err = r.resolveProxyBidsTx(ctx, tx, proxy.AuctionID, dbCurrentPrice, leaderID, rule.IncrementAt, proxy.UpdatedAt)
+		if err != nil || len(placedBids) == 0 {
+			return err
+		}
+
+		extension, err = r.extendForLateBidTx(ctx, tx, proxy.AuctionID, proxy.UpdatedAt)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return placedBids, extension, nil
+}
+
+/*
+PlaceWinningBidWithOCC places a bid that immediately wins the auction (e.g. buy now).
+ 1. Reading the current auction state
+ 2. Validating the expected price matches the actual price
+ 3. Checking the buyer's credit limit, see checkCreditTx
+ 4. Inserting the winning bid
+ 5. Ending the auction only if neither its price nor its status changed concurrently
+*/
+func (r *BidRepository) PlaceWinningBidWithOCC(ctx context.Context, winningBid *bid.Bid, expectedCurrentPrice shared.Money) error {
+	return r.conn.ExecuteTransaction(func(tx *sql.Tx) error {
+		auctionQuery := `
+			SELECT current_price, status, type, end_time
+			FROM auctions
+			WHERE id = $1
+		`
+
+		var dbCurrentPrice shared.Money
+		var status string
+		var auctionType auction.Type
+		var endTime time.Time
+		err := tx.QueryRowContext(ctx, auctionQuery, winningBid.AuctionID).Scan(&dbCurrentPrice, &status, &auctionType, &endTime)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return shared.ErrAuctionNotFound
+			}
+			return fmt.Errorf("failed to get auction for OCC: %w", err)
+		}
+
+		if !acceptsBidsAt(status, endTime, winningBid.CreatedAt) {
+			return shared.ErrAuctionNotAcceptingBids
+		}
+
+		if !dbCurrentPrice.Equal(expectedCurrentPrice) {
+			return shared.ErrAuctionPriceChanged
+		}
+
+		if winningBid.Amount.LessThan(dbCurrentPrice) {
+			return shared.ErrBidAmountTooLow
+		}
+
+		// Suppliers in reverse auctions are paid, so their bids do not use credit
+		if !auctionType.LowestBidWins() {
+			if err := r.checkCreditTx(ctx, tx, winningBid.UserID, winningBid.AuctionID, winningBid.Amount.Mul(winningBid.Quantity)); err != nil {
+				return err
+			}
+		}
+
+		if err := r.insertBidTx(ctx, tx, winningBid); err != nil {
+			return err
+		}
+
+		updateQuery := `
+			UPDATE auctions
+			SET current_price = $2, status = 'ended', updated_at = $3
+			WHERE id = $1 AND current_price = $4 AND status = 'active'
+		`
+
+		result, err := tx.ExecContext(ctx, updateQuery,
+			winningBid.AuctionID,
+			winningBid.Amount,
+			winningBid.CreatedAt,
+			expectedCurrentPrice,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to end auction: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		// Another bid or the scheduler got there first
+		if rowsAffected == 0 {
+			return shared.ErrAuctionPriceChanged
+		}
+
 		return nil
 	})
 }
+
+// PlaceSealedBid records a sealed bid; the auction price stays untouched until close
+func (r *BidRepository) PlaceSealedBid(ctx context.Context, sealedBid *bid.Bid) error {
+	return r.conn.ExecuteTransaction(func(tx *sql.Tx) error {
+		// Share-lock the auction so it cannot be closed while the bid is being recorded
+		auctionQuery := `
+			SELECT status, end_time
+			FROM auctions
+			WHERE id = $1
+			FOR SHARE
+		`
+
+		var status string
+		var endTime time.Time
+		err := tx.QueryRowContext(ctx, auctionQuery, sealedBid.AuctionID).Scan(&status, &endTime)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return shared.ErrAuctionNotFound
+			}
+			return fmt.Errorf("failed to get auction for sealed bid: %w", err)
+		}
+
+		if !acceptsBidsAt(status, endTime, sealedBid.CreatedAt) {
+			return shared.ErrAuctionNotAcceptingBids
+		}
+
+		if err := r.checkCreditTx(ctx, tx, sealedBid.UserID, sealedBid.AuctionID, sealedBid.Amount); err != nil {
+			return err
+		}
+
+		return r.insertBidTx(ctx, tx, sealedBid)
+	})
+}
+
+/*
+PlaceMultiUnitBid places a bid on a multi-unit auction.
+ 1. Locking the auction row so units are allocated one bid at a time
+ 2. Allocating the units among the accepted bids
+ 3. Requiring the bid to beat the entry price (the lowest winning bid once every unit is taken) by the increment
+ 4. Storing the new entry price as the auction's current price
+*/
+func (r *BidRepository) PlaceMultiUnitBid(ctx context.Context, newBid *bid.Bid) (shared.Money, *auction.Extension, error) {
+	var entryPrice shared.Money
+	var extension *auction.Extension
+
+	err := r.conn.ExecuteTransaction(func(tx *sql.Tx) error {
+		auctionQuery := `
+			SELECT status, starting_price, quantity, increment_rule, end_time
+			FROM auctions
+			WHERE id = $1
+			FOR UPDATE
+		`
+
+		var status string
+		var startingPrice shared.Money
+		var quantity int
+		var incrementRule []byte
+		var endTime time.Time
+		err := tx.QueryRowContext(ctx, auctionQuery, newBid.AuctionID).Scan(&status, &startingPrice, &quantity, &incrementRule, &endTime)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return shared.ErrAuctionNotFound
+			}
+			return fmt.Errorf("failed to get auction for multi-unit bid: %w", err)
+		}
+
+		if !acceptsBidsAt(status, endTime, newBid.CreatedAt) {
+			return shared.ErrAuctionNotAcceptingBids
+		}
+
+		if newBid.Quantity > quantity {
+			return shared.ErrInvalidBidQuantity
+		}
+
+		rule, err := unmarshalIncrementRule(incrementRule)
+		if err != nil {
+			return err
+		}
+
+		bidsQuery := `
+			SELECT ` + bidColumns + `
+			FROM bids
+			WHERE auction_id = $1 AND status = 'accepted'
+		`
+
+		rows, err := tx.QueryContext(ctx, bidsQuery, newBid.AuctionID)
+		if err != nil {
+			return fmt.Errorf("failed to get bids: %w", err)
+		}
+		bids, err := scanBids(rows)
+		if err != nil {
+			return err
+		}
+
+		current := &auction.Auction{
+			Type:          auction.TypeEnglish,
+			CurrentPrice:  bid.EntryPrice(bid.AllocateUnits(bids, quantity), quantity, startingPrice),
+			IncrementRule: rule,
+		}
+		if !current.MeetsIncrement(newBid.Amount) {
+			return shared.NewNextBidError(current.NextBid(), false)
+		}
+
+		if err := r.checkCreditTx(ctx, tx, newBid.UserID, newBid.AuctionID, newBid.Amount.Mul(newBid.Quantity)); err != nil {
+			return err
+		}
+
+		if err := r.insertBidTx(ctx, tx, newBid); err != nil {
+			return err
+		}
+
+		entryPrice = bid.EntryPrice(bid.AllocateUnits(append(bids, newBid), quantity), quantity, startingPrice)
+
+		updateQuery := `
+			UPDATE auctions
+			SET current_price = $2, updated_at = $3
+			WHERE id = $1
+		`
+
+		if _, err := tx.ExecContext(ctx, updateQuery, newBid.AuctionID, entryPrice, newBid.CreatedAt); err != nil {
+			return fmt.Errorf("failed to update auction price: %w", err)
+		}
+
+		extension, err = r.extendForLateBidTx(ctx, tx, newBid.AuctionID, newBid.CreatedAt)
+		return err
+	})
+	if err != nil {
+		return shared.Money{}, nil, err
+	}
+
+	return entryPrice, extension, nil
+}
+
+/*
+RetractBid withdraws an accepted bid.
+ 1. Locking the auction row so the price is recomputed one retraction or bid at a time
+ 2. Marking the bid retracted and dropping the bidder's proxy so it does not bid again
+ 3. Recomputing the price from the best remaining bid (the entry price for multi-unit
+    auctions, the starting price without bids); sealed auctions keep their hidden price
+ 4. Recording who retracted the bid, why, and the price change
+*/
+func (r *BidRepository) RetractBid(ctx context.Context, retraction *bid.Retraction) error {
+	return r.conn.ExecuteTransaction(func(tx *sql.Tx) error {
+		auctionQuery := `
+			SELECT status, type, starting_price, current_price, quantity, end_time
+			FROM auctions
+			WHERE id = $1
+			FOR UPDATE
+		`
+
+		var status string
+		var auctionType auction.Type
+		var startingPrice, currentPrice shared.Money
+		var quantity int
+		var endTime time.Time
+		err := tx.QueryRowContext(ctx, auctionQuery, retraction.AuctionID).Scan(&status, &auctionType, &startingPrice, &currentPrice, &quantity, &endTime)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return shared.ErrAuctionNotFound
+			}
+			return fmt.Errorf("failed to get auction for retraction: %w", err)
+		}
+
+		// The result is settled once the end time passes, even before the auction is closed
+		if !acceptsBidsAt(status, endTime, retraction.CreatedAt) {
+			return shared.ErrBidNotRetractable
+		}
+
+		var bidderID uuid.UUID
+		retractQuery := `
+			UPDATE bids
+			SET status = $3, updated_at = $4
+			WHERE id = $1 AND auction_id = $2 AND status = 'accepted'
+			RETURNING user_id
+		`
+
+		err = tx.QueryRowContext(ctx, retractQuery, retraction.BidID, retraction.AuctionID, bid.StatusRetracted, retraction.CreatedAt).Scan(&bidderID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return shared.ErrBidNotRetractable
+			}
+			return fmt.Errorf("failed to retract bid: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM proxy_bids WHERE auction_id = $1 AND user_id = $2`, retraction.AuctionID, bidderID); err != nil {
+			return fmt.Errorf("failed to remove proxy bid: %w", err)
+		}
+
+		current := &auction.Auction{Type: auctionType, Quantity: quantity}
+		newPrice := currentPrice
+		switch {
+		case current.IsSealed():
+			// Sealed auctions never reveal a price, so there is nothing to recompute
+		case current.IsMultiUnit():
+			bidsQuery := `
+				SELECT ` + bidColumns + `
+				FROM bids
+				WHERE auction_id = $1 AND status = 'accepted'
+			`
+
+			rows, err := tx.QueryContext(ctx, bidsQuery, retraction.AuctionID)
+			if err != nil {
+				return fmt.Errorf("failed to get bids: %w", err)
+			}
+			bids, err := scanBids(rows)
+			if err != nil {
+				return err
+			}
+
+			newPrice = bid.EntryPrice(bid.AllocateUnits(bids, quantity), quantity, startingPrice)
+		default:
+			order := "DESC"
+			if current.LowestBidWins() {
+				order = "ASC"
+			}
+
+			bestQuery := `
+				SELECT amount
+				FROM bids
+				WHERE auction_id = $1 AND status = 'accepted'
+				ORDER BY amount ` + order + `
+				LIMIT 1
+			`
+
+			err := tx.QueryRowContext(ctx, bestQuery, retraction.AuctionID).Scan(&newPrice)
+			if err == sql.ErrNoRows {
+				newPrice = startingPrice
+			} else if err != nil {
+				return fmt.Errorf("failed to get best remaining bid: %w", err)
+			}
+		}
+
+		if !newPrice.Equal(currentPrice) {
+			updateQuery := `
+				UPDATE auctions
+				SET current_price = $2, updated_at = $3
+				WHERE id = $1
+			`
+
+			if _, err := tx.ExecContext(ctx, updateQuery, retraction.AuctionID, newPrice, retraction.CreatedAt); err != nil {
+				return fmt.Errorf("failed to update auction price: %w", err)
+			}
+		}
+
+		retraction.PreviousPrice = currentPrice
+		retraction.NewPrice = newPrice
+
+		auditQuery := `
+			INSERT INTO bid_retractions (id, bid_id, auction_id, retracted_by, reason, previous_price, new_price, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		`
+
+		_, err = tx.ExecContext(ctx, auditQuery,
+			retraction.ID,
+			retraction.BidID,
+			retraction.AuctionID,
+			retraction.RetractedBy,
+			retraction.Reason,
+			retraction.PreviousPrice,
+			retraction.NewPrice,
+			retraction.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record bid retraction: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// resolveProxyBidsTx resolves competing proxies and persists the resulting bids and price
+func (r *BidRepository) resolveProxyBidsTx(ctx context.Context, tx *sql.Tx, auctionID uuid.UUID, currentPrice shared.Money, leaderID *uuid.UUID, incrementAt func(price shared.Money) shared.Money, now time.Time) ([]*bid.Bid, error) {
+	query := `
+		SELECT id, auction_id, user_id, max_amount, created_at, updated_at
+		FROM proxy_bids
+		WHERE auction_id = $1 AND max_amount > $2
+	`
+
+	rows, err := tx.QueryContext(ctx, query, auctionID, currentPrice)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proxy bids: %w", err)
+	}
+	defer rows.Close()
+
+	var proxies []*bid.ProxyBid
+	for rows.Next() {
+		var proxy bid.ProxyBid
+		err := rows.Scan(
+			&proxy.ID,
+			&proxy.AuctionID,
+			&proxy.UserID,
+			&proxy.MaxAmount,
+			&proxy.CreatedAt,
+			&proxy.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan proxy bid: %w", err)
+		}
+		proxies = append(proxies, &proxy)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating proxy bids: %w", err)
+	}
+
+	placedBids := bid.ResolveProxyBids(proxies, currentPrice, leaderID, incrementAt, now)
+	if len(placedBids) == 0 {
+		return nil, nil
+	}
+
+	for _, placedBid := range placedBids {
+		if err := r.insertBidTx(ctx, tx, placedBid); err != nil {
+			return nil, err
+		}
+	}
+
+	updateQuery := `
+		UPDATE auctions
+		SET current_price = $2, updated_at = $3
+		WHERE id = $1
+	`
+
+	if _, err := tx.ExecContext(ctx, updateQuery, auctionID, placedBids[len(placedBids)-1].Amount, now); err != nil {
+		return nil, fmt.Errorf("failed to update auction price: %w", err)
+	}
+
+	return placedBids, nil
+}
+
+// extendForLateBidTx applies the auction's soft-close window to a bid placed at bidTime.
+// The caller holds the auction row lock; the end time is only ever pushed forward, and
+// only while the auction is still active.
+func (r *BidRepository) extendForLateBidTx(ctx context.Context, tx *sql.Tx, auctionID uuid.UUID, bidTime time.Time) (*auction.Extension, error) {
+	query := `
+		SELECT end_time, soft_close_seconds
+		FROM auctions
+		WHERE id = $1
+	`
+
+	current := &auction.Auction{}
+	if err := tx.QueryRowContext(ctx, query, auctionID).Scan(&current.EndTime, &current.SoftCloseSeconds); err != nil {
+		return nil, fmt.Errorf("failed to get auction end time: %w", err)
+	}
+
+	previousEndTime := current.EndTime
+	if !current.ExtendForLateBid(bidTime) {
+		return nil, nil
+	}
+
+	updateQuery := `
+		UPDATE auctions
+		SET end_time = $1, updated_at = $3
+		WHERE id = $2 AND status = 'active' AND end_time < $1
+	`
+
+	result, err := tx.ExecContext(ctx, updateQuery, current.EndTime, auctionID, current.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extend auction: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return nil, nil
+	}
+
+	return &auction.Extension{PreviousEndTime: previousEndTime, EndTime: current.EndTime}, nil
+}
+
+// getLeaderTx returns the user currently holding the highest bid, or nil if there are no bids
+func (r *BidRepository) getLeaderTx(ctx context.Context, tx *sql.Tx, auctionID uuid.UUID) (*uuid.UUID, error) {
+	query := `
+		SELECT user_id
+		FROM bids
+		WHERE auction_id = $1 AND status = 'accepted'
+		ORDER BY amount DESC, created_at ASC
+		LIMIT 1
+	`
+
+	var leaderID uuid.UUID
+	err := tx.QueryRowContext(ctx, query, auctionID).Scan(&leaderID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get leading bid: %w", err)
+	}
+
+	return &leaderID, nil
+}
+
+/*
+openExposureQuery sums what a user stands to pay on active auctions, ahead of a new bid on $2,
+one row per auction currency:
+ 1. Single-unit auctions count the user's bid while it leads (sealed bids included), except
+    on $2, where the new bid replaces the lead
+ 2. Multi-unit auctions count every accepted bid, since each may win units; on $2 too, as
+    the new bid comes on top of the user's earlier ones
+
+Reverse auctions are left out, their winners are paid rather than pay.
+*/
+const openExposureQuery = `
+	SELECT currency, SUM(exposure)
+	FROM (
+		SELECT a.currency, leader.amount * leader.quantity AS exposure
+		FROM auctions a
+		CROSS JOIN LATERAL (
+			SELECT b.user_id, b.amount, b.quantity
+			FROM bids b
+			WHERE b.auction_id = a.id AND b.status = 'accepted'
+			ORDER BY b.amount DESC, b.created_at ASC
+			LIMIT 1
+		) leader
+		WHERE a.status = 'active' AND a.type <> 'reverse' AND a.quantity = 1 AND a.id <> $2
+		  AND leader.user_id = $1
+		  AND EXISTS (SELECT 1 FROM bids mine WHERE mine.auction_id = a.id AND mine.user_id = $1 AND mine.status = 'accepted')
+		UNION ALL
+		SELECT a.currency, b.amount * b.quantity AS exposure
+		FROM bids b
+		JOIN auctions a ON a.id = b.auction_id
+		WHERE a.status = 'active' AND a.type <> 'reverse' AND a.quantity > 1
+		  AND b.user_id = $1 AND b.status = 'accepted'
+	) open_bids
+	GROUP BY currency
+	ORDER BY currency
+`
+
+// queryer runs queries on either the database or a transaction
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// queryOpenExposure runs openExposureQuery, returning one amount per currency
+func queryOpenExposure(ctx context.Context, q queryer, userID, auctionID uuid.UUID) ([]shared.Money, error) {
+	rows, err := q.QueryContext(ctx, openExposureQuery, userID, auctionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open exposure: %w", err)
+	}
+	defer rows.Close()
+
+	var exposure []shared.Money
+	for rows.Next() {
+		var currency shared.Currency
+		var amount string
+		if err := rows.Scan(&currency, &amount); err != nil {
+			return nil, fmt.Errorf("failed to scan open exposure: %w", err)
+		}
+		parsed, err := shared.ParseMoney(amount, currency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse open exposure: %w", err)
+		}
+		exposure = append(exposure, parsed)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating open exposure: %w", err)
+	}
+
+	return exposure, nil
+}
+
+// GetOpenExposure sums what a user stands to pay on active auctions ahead of a new bid on
+// auctionID, one amount per currency, leaving out the single-unit lead that the new bid replaces
+func (r *BidRepository) GetOpenExposure(ctx context.Context, userID, auctionID uuid.UUID) ([]shared.Money, error) {
+	return queryOpenExposure(ctx, r.conn.GetDB(), userID, auctionID)
+}
+
+// checkCreditTx fails with a shared.CreditLimitError if a new commitment of amount on an auction
+// would take the user past their credit limit, converting amounts into the limit's currency.
+// The user row is locked so concurrent bids by the same user on different auctions are checked one at a time.
+func (r *BidRepository) checkCreditTx(ctx context.Context, tx *sql.Tx, userID, auctionID uuid.UUID, amount shared.Money) error {
+	var user shared.User
+	err := tx.QueryRowContext(ctx, `SELECT credit_limit FROM users WHERE id = $1 FOR UPDATE`, userID).Scan(&user.CreditLimit)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return shared.ErrUserNotFound
+		}
+		return fmt.Errorf("failed to get credit limit: %w", err)
+	}
+
+	if user.CreditLimit == nil {
+		return nil
+	}
+
+	exposure, err := queryOpenExposure(ctx, tx, userID, auctionID)
+	if err != nil {
+		return err
+	}
+
+	return user.CheckCredit(exposure, amount, r.rate(ctx))
+}
+
+// rate looks up exchange rates for the credit check; without a provider only equal currencies compare
+func (r *BidRepository) rate(ctx context.Context) shared.RateFunc {
+	if r.fxRates == nil {
+		return nil
+	}
+	return func(from, to shared.Currency) (float64, error) {
+		return r.fxRates.Rate(ctx, from, to)
+	}
+}
+
+// acceptsBidsAt returns true if an auction with the given status and end time still takes bids at t.
+// Between the end time and the scheduler closing the auction, bids are already refused.
+func acceptsBidsAt(status string, endTime, t time.Time) bool {
+	return status == "active" && t.Before(endTime)
+}
+
+// insertBidTx inserts a bid within a transaction
+func (r *BidRepository) insertBidTx(ctx context.Context, tx *sql.Tx, newBid *bid.Bid) error {
+	bidQuery := `
+		INSERT INTO bids (id, auction_id, user_id, amount, quantity, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := tx.ExecContext(ctx, bidQuery,
+		newBid.ID,
+		newBid.AuctionID,
+		newBid.UserID,
+		newBid.Amount,
+		newBid.Quantity,
+		newBid.Status,
+		newBid.CreatedAt,
+		newBid.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert bid: %w", err)
+	}
+
+	return nil
+}
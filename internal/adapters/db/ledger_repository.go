@@ -0,0 +1,156 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"troffee-auction-service/internal/domain/ledger"
+	"troffee-auction-service/internal/domain/shared"
+
+	"github.com/google/uuid"
+)
+
+// LedgerRepository implements the ledger repository interface
+type LedgerRepository struct {
+	conn *Connection
+}
+
+// NewLedgerRepository creates a new ledger repository
+func NewLedgerRepository(conn *Connection) *LedgerRepository {
+	return &LedgerRepository{conn: conn}
+}
+
+// postLedgerTx records a balanced transaction and its entries within tx, atomically with the change
+// being settled. A transaction of the same kind is only ever posted once per order.
+func postLedgerTx(ctx context.Context, tx *sql.Tx, transaction *ledger.Transaction) error {
+	if !transaction.Balanced() {
+		return shared.ErrLedgerUnbalanced
+	}
+
+	transactionQuery := `
+		INSERT INTO ledger_transactions (id, kind, order_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (kind, order_id) DO NOTHING
+		RETURNING id
+	`
+
+	var transactionID uuid.UUID
+	err := tx.QueryRowContext(ctx, transactionQuery,
+		transaction.ID,
+		transaction.Kind,
+		transaction.OrderID,
+		transaction.CreatedAt,
+	).Scan(&transactionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return shared.ErrLedgerAlreadyPosted
+		}
+		return fmt.Errorf("failed to create ledger transaction: %w", err)
+	}
+
+	entryQuery := `
+		INSERT INTO ledger_entries (id, transaction_id, account_type, user_id, kind, amount, currency, order_id, auction_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	for _, entry := range transaction.Entries {
+		_, err := tx.ExecContext(ctx, entryQuery,
+			entry.ID,
+			entry.TransactionID,
+			entry.Account.Type,
+			entry.Account.UserID,
+			entry.Kind,
+			entry.Amount,
+			entry.Currency,
+			entry.OrderID,
+			entry.AuctionID,
+			entry.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create ledger entry: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetUserBalances sums the entries posted to a user's account, one balance per currency
+func (r *LedgerRepository) GetUserBalances(ctx context.Context, userID uuid.UUID) ([]*ledger.Balance, error) {
+	query := `
+		SELECT currency, SUM(amount)
+		FROM ledger_entries
+		WHERE account_type = $1 AND user_id = $2
+		GROUP BY currency
+		ORDER BY currency
+	`
+
+	rows, err := r.conn.GetDB().QueryContext(ctx, query, ledger.AccountTypeUser, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user balances: %w", err)
+	}
+	defer rows.Close()
+
+	var balances []*ledger.Balance
+	for rows.Next() {
+		balance := ledger.Balance{UserID: userID}
+		if err := rows.Scan(&balance.Currency, &balance.Amount); err != nil {
+			return nil, fmt.Errorf("failed to scan user balance: %w", err)
+		}
+		balance.Amount = balance.Amount.WithCurrency(balance.Currency)
+
+		balances = append(balances, &balance)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating user balances: %w", err)
+	}
+
+	return balances, nil
+}
+
+// GetUserEntries retrieves the entries posted to a user's account, newest first
+func (r *LedgerRepository) GetUserEntries(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]*ledger.Entry, error) {
+	query := `
+		SELECT id, transaction_id, account_type, user_id, kind, amount, currency, order_id, auction_id, created_at
+		FROM ledger_entries
+		WHERE account_type = $1 AND user_id = $2
+		ORDER BY created_at DESC, id
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.conn.GetDB().QueryContext(ctx, query, ledger.AccountTypeUser, userID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledger entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*ledger.Entry
+	for rows.Next() {
+		var entry ledger.Entry
+		err := rows.Scan(
+			&entry.ID,
+			&entry.TransactionID,
+			&entry.Account.Type,
+			&entry.Account.UserID,
+			&entry.Kind,
+			&entry.Amount,
+			&entry.Currency,
+			&entry.OrderID,
+			&entry.AuctionID,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ledger entry: %w", err)
+		}
+		entry.Amount = entry.Amount.WithCurrency(entry.Currency)
+
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating ledger entries: %w", err)
+	}
+
+	return entries, nil
+}
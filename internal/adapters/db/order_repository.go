@@ -0,0 +1,226 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"troffee-auction-service/internal/domain/ledger"
+	"troffee-auction-service/internal/domain/order"
+	"troffee-auction-service/internal/domain/shared"
+
+	"github.com/google/uuid"
+)
+
+// orderColumns lists the order columns in the order scanOrder expects
+const orderColumns = `id, auction_id, bid_id, buyer_id, seller_id, quantity, unit_price,
+	hammer_price, buyer_premium, seller_fee, seller_payout, amount, currency, status,
+	COALESCE(payment_reference, ''), COALESCE(failure_reason, ''), due_at, paid_at, created_at, updated_at`
+
+// scanOrder scans a row selected with orderColumns into an order
+func scanOrder(row rowScanner) (*order.Order, error) {
+	var o order.Order
+	var paidAt sql.NullTime
+	err := row.Scan(
+		&o.ID,
+		&o.AuctionID,
+		&o.BidID,
+		&o.BuyerID,
+		&o.SellerID,
+		&o.Quantity,
+		&o.UnitPrice,
+		&o.HammerPrice,
+		&o.BuyerPremium,
+		&o.SellerFee,
+		&o.SellerPayout,
+		&o.Amount,
+		&o.Currency,
+		&o.Status,
+		&o.PaymentReference,
+		&o.FailureReason,
+		&o.DueAt,
+		&paidAt,
+		&o.CreatedAt,
+		&o.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if paidAt.Valid {
+		o.PaidAt = &paidAt.Time
+	}
+	o.SetCurrency(o.Currency)
+
+	return &o, nil
+}
+
+// OrderRepository implements the order repository interface
+type OrderRepository struct {
+	conn *Connection
+}
+
+// NewOrderRepository creates a new order repository
+func NewOrderRepository(conn *Connection) *OrderRepository {
+	return &OrderRepository{conn: conn}
+}
+
+// Create creates a new order
+func (r *OrderRepository) Create(ctx context.Context, o *order.Order) error {
+	query := `
+		INSERT INTO orders (id, auction_id, bid_id, buyer_id, seller_id, quantity, unit_price,
+			hammer_price, buyer_premium, seller_fee, seller_payout, amount, currency, status, due_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+	`
+
+	_, err := r.conn.GetDB().ExecContext(ctx, query,
+		o.ID,
+		o.AuctionID,
+		o.BidID,
+		o.BuyerID,
+		o.SellerID,
+		o.Quantity,
+		o.UnitPrice,
+		o.HammerPrice,
+		o.BuyerPremium,
+		o.SellerFee,
+		o.SellerPayout,
+		o.Amount,
+		o.Currency,
+		o.Status,
+		o.DueAt,
+		o.CreatedAt,
+		o.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create order: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an order by ID
+func (r *OrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*order.Order, error) {
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders
+		WHERE id = $1
+	`
+
+	o, err := scanOrder(r.conn.GetDB().QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, shared.ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	return o, nil
+}
+
+// GetByAuctionID retrieves all orders opened for an auction, oldest first
+func (r *OrderRepository) GetByAuctionID(ctx context.Context, auctionID uuid.UUID) ([]*order.Order, error) {
+	query := `
+		SELECT ` + orderColumns + `
+		FROM orders
+		WHERE auction_id = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.conn.GetDB().QueryContext(ctx, query, auctionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get orders: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []*order.Order
+	for rows.Next() {
+		o, err := scanOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan order: %w", err)
+		}
+		orders = append(orders, o)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating orders: %w", err)
+	}
+
+	return orders, nil
+}
+
+// UpdateWithOCC saves an order's payment state only if it still has the expected status
+func (r *OrderRepository) UpdateWithOCC(ctx context.Context, o *order.Order, expected order.Status) error {
+	query := `
+		UPDATE orders
+		SET status = $2, payment_reference = NULLIF($3, ''), failure_reason = NULLIF($4, ''), paid_at = $5, updated_at = $6
+		WHERE id = $1 AND status = $7
+	`
+
+	result, err := r.conn.GetDB().ExecContext(ctx, query,
+		o.ID,
+		o.Status,
+		o.PaymentReference,
+		o.FailureReason,
+		o.PaidAt,
+		o.UpdatedAt,
+		expected,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update order: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return shared.ErrOrderStatusChanged
+	}
+
+	return nil
+}
+
+// UpdatePaidWithOCC saves a paid order only if it still has the expected status, and posts its
+// settlement to the ledger in the same transaction. A settlement posted before is left as it is.
+func (r *OrderRepository) UpdatePaidWithOCC(ctx context.Context, o *order.Order, expected order.Status, settlement *ledger.Transaction) error {
+	return r.conn.ExecuteTransaction(func(tx *sql.Tx) error {
+		query := `
+			UPDATE orders
+			SET status = $2, payment_reference = NULLIF($3, ''), failure_reason = NULLIF($4, ''), paid_at = $5, updated_at = $6
+			WHERE id = $1 AND status = $7
+		`
+
+		result, err := tx.ExecContext(ctx, query,
+			o.ID,
+			o.Status,
+			o.PaymentReference,
+			o.FailureReason,
+			o.PaidAt,
+			o.UpdatedAt,
+			expected,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return shared.ErrOrderStatusChanged
+		}
+
+		if settlement == nil {
+			return nil
+		}
+		if err := postLedgerTx(ctx, tx, settlement); err != nil && err != shared.ErrLedgerAlreadyPosted {
+			return err
+		}
+
+		return nil
+	})
+}
@@ -6,12 +6,13 @@ import (
 
 // RepositoryFactory creates and manages all database repositories
 type RepositoryFactory struct {
-	conn *Connection
+	conn    *Connection
+	fxRates outbound.FXRateProvider
 }
 
-// NewRepositoryFactory creates a new repository factory
-func NewRepositoryFactory(conn *Connection) *RepositoryFactory {
-	return &RepositoryFactory{conn: conn}
+// NewRepositoryFactory creates a new repository factory; fxRates converts amounts for credit checks
+func NewRepositoryFactory(conn *Connection, fxRates outbound.FXRateProvider) *RepositoryFactory {
+	return &RepositoryFactory{conn: conn, fxRates: fxRates}
 }
 
 // GetAuctionRepository returns the auction repository
@@ -21,7 +22,22 @@ func (f *RepositoryFactory) GetAuctionRepository() outbound.AuctionRepository {
 
 // GetBidRepository returns the bid repository
 func (f *RepositoryFactory) GetBidRepository() outbound.BidRepository {
-	return NewBidRepository(f.conn)
+	return NewBidRepository(f.conn, f.fxRates)
+}
+
+// GetSecondChanceOfferRepository returns the second-chance offer repository
+func (f *RepositoryFactory) GetSecondChanceOfferRepository() outbound.SecondChanceOfferRepository {
+	return NewSecondChanceOfferRepository(f.conn)
+}
+
+// GetOrderRepository returns the order repository
+func (f *RepositoryFactory) GetOrderRepository() outbound.OrderRepository {
+	return NewOrderRepository(f.conn)
+}
+
+// GetLedgerRepository returns the ledger repository
+func (f *RepositoryFactory) GetLedgerRepository() outbound.LedgerRepository {
+	return NewLedgerRepository(f.conn)
 }
 
 // GetItemRepository returns the item repository
@@ -0,0 +1,164 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"troffee-auction-service/internal/domain/auction"
+	"troffee-auction-service/internal/domain/shared"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// openOfferIndex is the unique index allowing one pending or accepted offer per auction
+const openOfferIndex = "idx_second_chance_offers_open"
+
+// offerColumns lists the second-chance offer columns in the order scanOffer expects
+const offerColumns = `id, auction_id, bid_id, user_id, amount, status, expires_at, created_at, updated_at`
+
+// isOpenOfferConflict returns true if err is a violation of openOfferIndex
+func isOpenOfferConflict(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == openOfferIndex
+}
+
+// scanOffer scans a row selected with offerColumns into an offer
+func scanOffer(row rowScanner) (*auction.SecondChanceOffer, error) {
+	var offer auction.SecondChanceOffer
+	err := row.Scan(
+		&offer.ID,
+		&offer.AuctionID,
+		&offer.BidID,
+		&offer.UserID,
+		&offer.Amount,
+		&offer.Status,
+		&offer.ExpiresAt,
+		&offer.CreatedAt,
+		&offer.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &offer, nil
+}
+
+// SecondChanceOfferRepository implements the second-chance offer repository interface
+type SecondChanceOfferRepository struct {
+	conn *Connection
+}
+
+// NewSecondChanceOfferRepository creates a new second-chance offer repository
+func NewSecondChanceOfferRepository(conn *Connection) *SecondChanceOfferRepository {
+	return &SecondChanceOfferRepository{conn: conn}
+}
+
+// Create creates a new offer
+func (r *SecondChanceOfferRepository) Create(ctx context.Context, offer *auction.SecondChanceOffer) error {
+	query := `
+		INSERT INTO second_chance_offers (id, auction_id, bid_id, user_id, amount, status, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.conn.GetDB().ExecContext(ctx, query,
+		offer.ID,
+		offer.AuctionID,
+		offer.BidID,
+		offer.UserID,
+		offer.Amount,
+		offer.Status,
+		offer.ExpiresAt,
+		offer.CreatedAt,
+		offer.UpdatedAt,
+	)
+	if err != nil {
+		if isOpenOfferConflict(err) {
+			return shared.ErrSecondChanceOfferOpen
+		}
+		return fmt.Errorf("failed to create second-chance offer: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an offer by ID
+func (r *SecondChanceOfferRepository) GetByID(ctx context.Context, id uuid.UUID) (*auction.SecondChanceOffer, error) {
+	query := `
+		SELECT ` + offerColumns + `
+		FROM second_chance_offers
+		WHERE id = $1
+	`
+
+	offer, err := scanOffer(r.conn.GetDB().QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, shared.ErrSecondChanceOfferNotFound
+		}
+		return nil, fmt.Errorf("failed to get second-chance offer: %w", err)
+	}
+
+	return offer, nil
+}
+
+// GetByAuctionID retrieves all offers made for an auction, oldest first
+func (r *SecondChanceOfferRepository) GetByAuctionID(ctx context.Context, auctionID uuid.UUID) ([]*auction.SecondChanceOffer, error) {
+	query := `
+		SELECT ` + offerColumns + `
+		FROM second_chance_offers
+		WHERE auction_id = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.conn.GetDB().QueryContext(ctx, query, auctionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get second-chance offers: %w", err)
+	}
+	defer rows.Close()
+
+	var offers []*auction.SecondChanceOffer
+	for rows.Next() {
+		offer, err := scanOffer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan second-chance offer: %w", err)
+		}
+		offers = append(offers, offer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating second-chance offers: %w", err)
+	}
+
+	return offers, nil
+}
+
+// UpdateStatusWithOCC moves an offer to a new status only if it still has the expected one
+func (r *SecondChanceOfferRepository) UpdateStatusWithOCC(ctx context.Context, offerID uuid.UUID, expected, status auction.OfferStatus) error {
+	query := `
+		UPDATE second_chance_offers
+		SET status = $2, updated_at = $3
+		WHERE id = $1 AND status = $4
+	`
+
+	result, err := r.conn.GetDB().ExecContext(ctx, query, offerID, status, time.Now(), expected)
+	if err != nil {
+		if isOpenOfferConflict(err) {
+			return shared.ErrSecondChanceAlreadyAccepted
+		}
+		return fmt.Errorf("failed to update second-chance offer status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return shared.ErrSecondChanceStatusChanged
+	}
+
+	return nil
+}
@@ -23,7 +23,7 @@ func NewUserRepository(conn *Connection) *UserRepository {
 // GetByID retrieves a user by ID
 func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*shared.User, error) {
 	query := `
-		SELECT id, name
+		SELECT id, name, role, credit_limit
 		FROM users
 		WHERE id = $1
 	`
@@ -32,6 +32,8 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*shared.Use
 	err := r.conn.GetDB().QueryRowContext(ctx, query, id).Scan(
 		&user.ID,
 		&user.Name,
+		&user.Role,
+		&user.CreditLimit,
 	)
 
 	if err != nil {
@@ -47,13 +49,19 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*shared.Use
 // Create creates a new user
 func (r *UserRepository) Create(ctx context.Context, user *shared.User) error {
 	query := `
-		INSERT INTO users (id, name)
-		VALUES ($1, $2)
+		INSERT INTO users (id, name, role, credit_limit)
+		VALUES ($1, $2, $3, $4)
 	`
 
+	if user.Role == "" {
+		user.Role = shared.RoleUser
+	}
+
 	_, err := r.conn.GetDB().ExecContext(ctx, query,
 		user.ID,
 		user.Name,
+		user.Role,
+		user.CreditLimit,
 	)
 
 	if err != nil {
@@ -62,3 +70,28 @@ func (r *UserRepository) Create(ctx context.Context, user *shared.User) error {
 
 	return nil
 }
+
+// UpdateCreditLimit sets a user's credit limit; nil removes the limit
+func (r *UserRepository) UpdateCreditLimit(ctx context.Context, userID uuid.UUID, creditLimit *shared.Money) error {
+	query := `
+		UPDATE users
+		SET credit_limit = $2
+		WHERE id = $1
+	`
+
+	result, err := r.conn.GetDB().ExecContext(ctx, query, userID, creditLimit)
+	if err != nil {
+		return fmt.Errorf("failed to update credit limit: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return shared.ErrUserNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package fx
+
+import (
+	"context"
+
+	"troffee-auction-service/internal/domain/shared"
+
+	"github.com/rs/zerolog"
+)
+
+// StaticRateProvider answers exchange rates from a fixed table of rates against a base
+// currency. It is meant for local runs; a live rate feed can replace it behind the same port.
+type StaticRateProvider struct {
+	base   shared.Currency
+	rates  map[shared.Currency]float64 // currency -> units per one unit of base
+	logger zerolog.Logger
+}
+type StaticRateProviderParams struct {
+	// Base is the currency the rates are quoted against; it defaults to USD
+	Base   shared.Currency
+	Rates  map[shared.Currency]float64
+	Logger zerolog.Logger
+}
+
+// NewStaticRateProvider creates a new static exchange rate provider
+func NewStaticRateProvider(params StaticRateProviderParams) *StaticRateProvider {
+	base := params.Base
+	if base == "" {
+		base = shared.DefaultCurrency
+	}
+
+	rates := make(map[shared.Currency]float64, len(params.Rates)+1)
+	for currency, rate := range params.Rates {
+		if rate > 0 {
+			rates[currency] = rate
+		}
+	}
+	rates[base] = 1
+
+	return &StaticRateProvider{
+		base:   base,
+		rates:  rates,
+		logger: params.Logger.With().Str("component", "static_fx_rates").Logger(),
+	}
+}
+
+// Rate returns the cross rate between two currencies through the base currency
+func (p *StaticRateProvider) Rate(ctx context.Context, from, to shared.Currency) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+
+	fromRate, ok := p.rates[from]
+	if !ok {
+		p.logger.Debug().Str("currency", string(from)).Msg("No exchange rate for currency")
+		return 0, shared.ErrFXRateUnavailable
+	}
+	toRate, ok := p.rates[to]
+	if !ok {
+		p.logger.Debug().Str("currency", string(to)).Msg("No exchange rate for currency")
+		return 0, shared.ErrFXRateUnavailable
+	}
+
+	return toRate / fromRate, nil
+}
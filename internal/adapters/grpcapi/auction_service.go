@@ -0,0 +1,308 @@
+package grpcapi
+
+import (
+	"context"
+
+	"troffee-auction-service/internal/adapters/grpcapi/auctionv1"
+	"troffee-auction-service/internal/domain/auction"
+	"troffee-auction-service/internal/ports/inbound"
+	"troffee-auction-service/internal/ports/outbound"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// auctionServer implements the AuctionService RPCs on top of inbound.AuctionService
+type auctionServer struct {
+	auctionv1.UnimplementedAuctionServiceServer
+	auctionService  inbound.AuctionService
+	broadcaster     outbound.Broadcaster
+	buyNowThreshold float64
+	shutdown        <-chan struct{}
+	logger          zerolog.Logger
+}
+
+func (server *auctionServer) CreateAuction(ctx context.Context, req *auctionv1.CreateAuctionRequest) (*auctionv1.Auction, error) {
+	itemID, err := parseID("item_id", req.ItemId)
+	if err != nil {
+		return nil, err
+	}
+	creatorID, err := parseID("creator_id", req.CreatorId)
+	if err != nil {
+		return nil, err
+	}
+	startingPrice, err := parseMoney("starting_price", req.StartingPrice)
+	if err != nil {
+		return nil, err
+	}
+	reservePrice, err := parseOptionalMoney("reserve_price", req.ReservePrice)
+	if err != nil {
+		return nil, err
+	}
+	buyNowPrice, err := parseOptionalMoney("buy_now_price", req.BuyNowPrice)
+	if err != nil {
+		return nil, err
+	}
+	priceDropAmount, err := parseMoney("price_drop_amount", req.PriceDropAmount)
+	if err != nil {
+		return nil, err
+	}
+	floorPrice, err := parseMoney("floor_price", req.FloorPrice)
+	if err != nil {
+		return nil, err
+	}
+	currency, err := parseCurrency(req.Currency)
+	if err != nil {
+		return nil, err
+	}
+	incrementRule, err := incrementRuleFromProto(req.IncrementRule)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := server.auctionService.CreateAuction(ctx, inbound.CreateAuctionRequest{
+		ItemID:           itemID,
+		CreatorID:        creatorID,
+		StartTime:        formatTime(req.StartTime),
+		EndTime:          formatTime(req.EndTime),
+		StartingPrice:    startingPrice,
+		SoftCloseSeconds: int(req.SoftCloseSeconds),
+		ReservePrice:     reservePrice,
+		BuyNowPrice:      buyNowPrice,
+		Currency:         currency,
+		IncrementRule:    incrementRule,
+		Quantity:         int(req.Quantity),
+		Pricing:          auction.Pricing(req.Pricing),
+
+		Type:                     auction.Type(req.Type),
+		PriceDropAmount:          priceDropAmount,
+		PriceDropIntervalSeconds: int(req.PriceDropIntervalSeconds),
+		FloorPrice:               floorPrice,
+
+		RelistPolicy: relistPolicyFromProto(req.RelistPolicy),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return auctionToProto(created, server.buyNowThreshold), nil
+}
+
+func (server *auctionServer) GetAuction(ctx context.Context, req *auctionv1.GetAuctionRequest) (*auctionv1.Auction, error) {
+	auctionID, err := parseID("auction_id", req.AuctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := server.auctionService.GetAuction(ctx, auctionID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return auctionToProto(found, server.buyNowThreshold), nil
+}
+
+func (server *auctionServer) ListAuctions(ctx context.Context, req *auctionv1.ListAuctionsRequest) (*auctionv1.ListAuctionsResponse, error) {
+	listRequest := inbound.ListAuctionsRequest{
+		Page:     int(req.Page),
+		PageSize: int(req.PageSize),
+	}
+	if req.Status != nil {
+		auctionStatus := auction.Status(*req.Status)
+		listRequest.Status = &auctionStatus
+	}
+
+	auctions, err := server.auctionService.ListAuctions(ctx, listRequest)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &auctionv1.ListAuctionsResponse{}
+	for _, a := range auctions {
+		response.Auctions = append(response.Auctions, auctionToProto(a, server.buyNowThreshold))
+	}
+	return response, nil
+}
+
+func (server *auctionServer) EndAuction(ctx context.Context, req *auctionv1.EndAuctionRequest) (*auctionv1.EndAuctionResponse, error) {
+	auctionID, err := parseID("auction_id", req.AuctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := server.auctionService.EndAuction(ctx, auctionID); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &auctionv1.EndAuctionResponse{}, nil
+}
+
+func (server *auctionServer) UpdateAuction(ctx context.Context, req *auctionv1.UpdateAuctionRequest) (*auctionv1.Auction, error) {
+	auctionID, err := parseID("auction_id", req.AuctionId)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := parseID("user_id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	startingPrice, err := parseOptionalMoney("starting_price", req.StartingPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	updateRequest := inbound.UpdateAuctionRequest{
+		AuctionID:     auctionID,
+		UserID:        userID,
+		StartingPrice: startingPrice,
+	}
+	if req.EndTime != nil {
+		endTime := formatTime(req.EndTime)
+		updateRequest.EndTime = &endTime
+	}
+
+	updated, err := server.auctionService.UpdateAuction(ctx, updateRequest)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return auctionToProto(updated, server.buyNowThreshold), nil
+}
+
+func (server *auctionServer) CancelAuction(ctx context.Context, req *auctionv1.CancelAuctionRequest) (*auctionv1.Auction, error) {
+	auctionID, err := parseID("auction_id", req.AuctionId)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := parseID("user_id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelled, err := server.auctionService.CancelAuction(ctx, inbound.CancelAuctionRequest{
+		AuctionID: auctionID,
+		UserID:    userID,
+		Reason:    req.Reason,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return auctionToProto(cancelled, server.buyNowThreshold), nil
+}
+
+func (server *auctionServer) OfferSecondChance(ctx context.Context, req *auctionv1.OfferSecondChanceRequest) (*auctionv1.SecondChanceOffer, error) {
+	auctionID, err := parseID("auction_id", req.AuctionId)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := parseID("user_id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	offer, err := server.auctionService.OfferSecondChance(ctx, inbound.SecondChanceOfferRequest{
+		AuctionID: auctionID,
+		UserID:    userID,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return secondChanceOfferToProto(offer), nil
+}
+
+func (server *auctionServer) AcceptSecondChanceOffer(ctx context.Context, req *auctionv1.SecondChanceAnswerRequest) (*auctionv1.AuctionEndResult, error) {
+	answer, err := secondChanceAnswer(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := server.auctionService.AcceptSecondChanceOffer(ctx, answer)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return endResultToProto(result), nil
+}
+
+func (server *auctionServer) DeclineSecondChanceOffer(ctx context.Context, req *auctionv1.SecondChanceAnswerRequest) (*auctionv1.SecondChanceOffer, error) {
+	answer, err := secondChanceAnswer(req)
+	if err != nil {
+		return nil, err
+	}
+
+	offer, err := server.auctionService.DeclineSecondChanceOffer(ctx, answer)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return secondChanceOfferToProto(offer), nil
+}
+
+// WatchAuction subscribes the stream to the auction's broadcast events, the same ones
+// WebSocket subscribers receive, and forwards them until the client goes away or the server stops
+func (server *auctionServer) WatchAuction(req *auctionv1.WatchAuctionRequest, stream auctionv1.AuctionService_WatchAuctionServer) error {
+	ctx := stream.Context()
+
+	auctionID, err := parseID("auction_id", req.AuctionId)
+	if err != nil {
+		return err
+	}
+	if _, err := server.auctionService.GetAuction(ctx, auctionID); err != nil {
+		return toStatus(err)
+	}
+
+	// Streams of the same auction share its subscription. The broadcaster never closes the event
+	// channel; nothing is delivered to it once unwatched.
+	eventChan := make(chan outbound.Event, 100)
+	if err := server.broadcaster.Watch(ctx, auctionID, eventChan); err != nil {
+		server.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to subscribe gRPC stream to auction")
+		return status.Error(codes.Unavailable, "failed to subscribe to auction events")
+	}
+	defer server.broadcaster.Unwatch(context.Background(), auctionID, eventChan)
+
+	server.logger.Info().Str("auction_id", auctionID.String()).Msg("gRPC client watching auction")
+
+	for {
+		select {
+		case <-ctx.Done():
+			server.logger.Info().Str("auction_id", auctionID.String()).Msg("gRPC client stopped watching auction")
+			return nil
+
+		case <-server.shutdown:
+			return status.Error(codes.Unavailable, "server is shutting down")
+
+		case event := <-eventChan:
+			data, err := structpb.NewStruct(event.Data)
+			if err != nil {
+				server.logger.Error().Err(err).Str("event_type", string(event.Type)).Msg("Failed to convert event data for gRPC stream")
+				continue
+			}
+
+			if err := stream.Send(&auctionv1.AuctionEvent{
+				Type:      string(event.Type),
+				AuctionId: event.AuctionID.String(),
+				Data:      data,
+				Timestamp: event.Timestamp,
+				Seq:       event.Seq,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func secondChanceAnswer(req *auctionv1.SecondChanceAnswerRequest) (inbound.SecondChanceAnswerRequest, error) {
+	offerID, err := parseID("offer_id", req.OfferId)
+	if err != nil {
+		return inbound.SecondChanceAnswerRequest{}, err
+	}
+	userID, err := parseID("user_id", req.UserId)
+	if err != nil {
+		return inbound.SecondChanceAnswerRequest{}, err
+	}
+	return inbound.SecondChanceAnswerRequest{OfferID: offerID, UserID: userID}, nil
+}
@@ -0,0 +1,839 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: auction/v1/auction.proto
+
+package auctionv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AuctionService_CreateAuction_FullMethodName            = "/troffee.auction.v1.AuctionService/CreateAuction"
+	AuctionService_GetAuction_FullMethodName               = "/troffee.auction.v1.AuctionService/GetAuction"
+	AuctionService_ListAuctions_FullMethodName             = "/troffee.auction.v1.AuctionService/ListAuctions"
+	AuctionService_EndAuction_FullMethodName               = "/troffee.auction.v1.AuctionService/EndAuction"
+	AuctionService_UpdateAuction_FullMethodName            = "/troffee.auction.v1.AuctionService/UpdateAuction"
+	AuctionService_CancelAuction_FullMethodName            = "/troffee.auction.v1.AuctionService/CancelAuction"
+	AuctionService_OfferSecondChance_FullMethodName        = "/troffee.auction.v1.AuctionService/OfferSecondChance"
+	AuctionService_AcceptSecondChanceOffer_FullMethodName  = "/troffee.auction.v1.AuctionService/AcceptSecondChanceOffer"
+	AuctionService_DeclineSecondChanceOffer_FullMethodName = "/troffee.auction.v1.AuctionService/DeclineSecondChanceOffer"
+	AuctionService_WatchAuction_FullMethodName             = "/troffee.auction.v1.AuctionService/WatchAuction"
+)
+
+// AuctionServiceClient is the client API for AuctionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AuctionService manages auctions and streams their events
+type AuctionServiceClient interface {
+	// CreateAuction creates a new auction
+	CreateAuction(ctx context.Context, in *CreateAuctionRequest, opts ...grpc.CallOption) (*Auction, error)
+	// GetAuction retrieves an auction by ID
+	GetAuction(ctx context.Context, in *GetAuctionRequest, opts ...grpc.CallOption) (*Auction, error)
+	// ListAuctions retrieves a page of auctions, optionally filtered by status
+	ListAuctions(ctx context.Context, in *ListAuctionsRequest, opts ...grpc.CallOption) (*ListAuctionsResponse, error)
+	// EndAuction ends an auction whose end time has been reached
+	EndAuction(ctx context.Context, in *EndAuctionRequest, opts ...grpc.CallOption) (*EndAuctionResponse, error)
+	// UpdateAuction changes the terms of an auction that has no bids yet
+	UpdateAuction(ctx context.Context, in *UpdateAuctionRequest, opts ...grpc.CallOption) (*Auction, error)
+	// CancelAuction withdraws an auction on behalf of its creator or an admin
+	CancelAuction(ctx context.Context, in *CancelAuctionRequest, opts ...grpc.CallOption) (*Auction, error)
+	// OfferSecondChance offers the item of an ended auction to the next runner-up
+	OfferSecondChance(ctx context.Context, in *OfferSecondChanceRequest, opts ...grpc.CallOption) (*SecondChanceOffer, error)
+	// AcceptSecondChanceOffer sells the item to the runner-up at the offered price
+	AcceptSecondChanceOffer(ctx context.Context, in *SecondChanceAnswerRequest, opts ...grpc.CallOption) (*AuctionEndResult, error)
+	// DeclineSecondChanceOffer turns a second-chance offer down
+	DeclineSecondChanceOffer(ctx context.Context, in *SecondChanceAnswerRequest, opts ...grpc.CallOption) (*SecondChanceOffer, error)
+	// WatchAuction streams the events broadcast for an auction until the client cancels
+	WatchAuction(ctx context.Context, in *WatchAuctionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AuctionEvent], error)
+}
+
+type auctionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuctionServiceClient(cc grpc.ClientConnInterface) AuctionServiceClient {
+	return &auctionServiceClient{cc}
+}
+
+func (c *auctionServiceClient) CreateAuction(ctx context.Context, in *CreateAuctionRequest, opts ...grpc.CallOption) (*Auction, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Auction)
+	err := c.cc.Invoke(ctx, AuctionService_CreateAuction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionServiceClient) GetAuction(ctx context.Context, in *GetAuctionRequest, opts ...grpc.CallOption) (*Auction, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Auction)
+	err := c.cc.Invoke(ctx, AuctionService_GetAuction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionServiceClient) ListAuctions(ctx context.Context, in *ListAuctionsRequest, opts ...grpc.CallOption) (*ListAuctionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuctionsResponse)
+	err := c.cc.Invoke(ctx, AuctionService_ListAuctions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionServiceClient) EndAuction(ctx context.Context, in *EndAuctionRequest, opts ...grpc.CallOption) (*EndAuctionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EndAuctionResponse)
+	err := c.cc.Invoke(ctx, AuctionService_EndAuction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionServiceClient) UpdateAuction(ctx context.Context, in *UpdateAuctionRequest, opts ...grpc.CallOption) (*Auction, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Auction)
+	err := c.cc.Invoke(ctx, AuctionService_UpdateAuction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionServiceClient) CancelAuction(ctx context.Context, in *CancelAuctionRequest, opts ...grpc.CallOption) (*Auction, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Auction)
+	err := c.cc.Invoke(ctx, AuctionService_CancelAuction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionServiceClient) OfferSecondChance(ctx context.Context, in *OfferSecondChanceRequest, opts ...grpc.CallOption) (*SecondChanceOffer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SecondChanceOffer)
+	err := c.cc.Invoke(ctx, AuctionService_OfferSecondChance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionServiceClient) AcceptSecondChanceOffer(ctx context.Context, in *SecondChanceAnswerRequest, opts ...grpc.CallOption) (*AuctionEndResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuctionEndResult)
+	err := c.cc.Invoke(ctx, AuctionService_AcceptSecondChanceOffer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionServiceClient) DeclineSecondChanceOffer(ctx context.Context, in *SecondChanceAnswerRequest, opts ...grpc.CallOption) (*SecondChanceOffer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SecondChanceOffer)
+	err := c.cc.Invoke(ctx, AuctionService_DeclineSecondChanceOffer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionServiceClient) WatchAuction(ctx context.Context, in *WatchAuctionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AuctionEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AuctionService_ServiceDesc.Streams[0], AuctionService_WatchAuction_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchAuctionRequest, AuctionEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AuctionService_WatchAuctionClient = grpc.ServerStreamingClient[AuctionEvent]
+
+// AuctionServiceServer is the server API for AuctionService service.
+// All implementations must embed UnimplementedAuctionServiceServer
+// for forward compatibility.
+//
+// AuctionService manages auctions and streams their events
+type AuctionServiceServer interface {
+	// CreateAuction creates a new auction
+	CreateAuction(context.Context, *CreateAuctionRequest) (*Auction, error)
+	// GetAuction retrieves an auction by ID
+	GetAuction(context.Context, *GetAuctionRequest) (*Auction, error)
+	// ListAuctions retrieves a page of auctions, optionally filtered by status
+	ListAuctions(context.Context, *ListAuctionsRequest) (*ListAuctionsResponse, error)
+	// EndAuction ends an auction whose end time has been reached
+	EndAuction(context.Context, *EndAuctionRequest) (*EndAuctionResponse, error)
+	// UpdateAuction changes the terms of an auction that has no bids yet
+	UpdateAuction(context.Context, *UpdateAuctionRequest) (*Auction, error)
+	// CancelAuction withdraws an auction on behalf of its creator or an admin
+	CancelAuction(context.Context, *CancelAuctionRequest) (*Auction, error)
+	// OfferSecondChance offers the item of an ended auction to the next runner-up
+	OfferSecondChance(context.Context, *OfferSecondChanceRequest) (*SecondChanceOffer, error)
+	// AcceptSecondChanceOffer sells the item to the runner-up at the offered price
+	AcceptSecondChanceOffer(context.Context, *SecondChanceAnswerRequest) (*AuctionEndResult, error)
+	// DeclineSecondChanceOffer turns a second-chance offer down
+	DeclineSecondChanceOffer(context.Context, *SecondChanceAnswerRequest) (*SecondChanceOffer, error)
+	// WatchAuction streams the events broadcast for an auction until the client cancels
+	WatchAuction(*WatchAuctionRequest, grpc.ServerStreamingServer[AuctionEvent]) error
+	mustEmbedUnimplementedAuctionServiceServer()
+}
+
+// UnimplementedAuctionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAuctionServiceServer struct{}
+
+func (UnimplementedAuctionServiceServer) CreateAuction(context.Context, *CreateAuctionRequest) (*Auction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAuction not implemented")
+}
+func (UnimplementedAuctionServiceServer) GetAuction(context.Context, *GetAuctionRequest) (*Auction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuction not implemented")
+}
+func (UnimplementedAuctionServiceServer) ListAuctions(context.Context, *ListAuctionsRequest) (*ListAuctionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuctions not implemented")
+}
+func (UnimplementedAuctionServiceServer) EndAuction(context.Context, *EndAuctionRequest) (*EndAuctionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EndAuction not implemented")
+}
+func (UnimplementedAuctionServiceServer) UpdateAuction(context.Context, *UpdateAuctionRequest) (*Auction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAuction not implemented")
+}
+func (UnimplementedAuctionServiceServer) CancelAuction(context.Context, *CancelAuctionRequest) (*Auction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelAuction not implemented")
+}
+func (UnimplementedAuctionServiceServer) OfferSecondChance(context.Context, *OfferSecondChanceRequest) (*SecondChanceOffer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OfferSecondChance not implemented")
+}
+func (UnimplementedAuctionServiceServer) AcceptSecondChanceOffer(context.Context, *SecondChanceAnswerRequest) (*AuctionEndResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptSecondChanceOffer not implemented")
+}
+func (UnimplementedAuctionServiceServer) DeclineSecondChanceOffer(context.Context, *SecondChanceAnswerRequest) (*SecondChanceOffer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeclineSecondChanceOffer not implemented")
+}
+func (UnimplementedAuctionServiceServer) WatchAuction(*WatchAuctionRequest, grpc.ServerStreamingServer[AuctionEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchAuction not implemented")
+}
+func (UnimplementedAuctionServiceServer) mustEmbedUnimplementedAuctionServiceServer() {}
+func (UnimplementedAuctionServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeAuctionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuctionServiceServer will
+// result in compilation errors.
+type UnsafeAuctionServiceServer interface {
+	mustEmbedUnimplementedAuctionServiceServer()
+}
+
+func RegisterAuctionServiceServer(s grpc.ServiceRegistrar, srv AuctionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAuctionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AuctionService_ServiceDesc, srv)
+}
+
+func _AuctionService_CreateAuction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAuctionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionServiceServer).CreateAuction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuctionService_CreateAuction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionServiceServer).CreateAuction(ctx, req.(*CreateAuctionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionService_GetAuction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuctionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionServiceServer).GetAuction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuctionService_GetAuction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionServiceServer).GetAuction(ctx, req.(*GetAuctionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionService_ListAuctions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuctionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionServiceServer).ListAuctions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuctionService_ListAuctions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionServiceServer).ListAuctions(ctx, req.(*ListAuctionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionService_EndAuction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndAuctionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionServiceServer).EndAuction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuctionService_EndAuction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionServiceServer).EndAuction(ctx, req.(*EndAuctionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionService_UpdateAuction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAuctionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionServiceServer).UpdateAuction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuctionService_UpdateAuction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionServiceServer).UpdateAuction(ctx, req.(*UpdateAuctionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionService_CancelAuction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelAuctionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionServiceServer).CancelAuction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuctionService_CancelAuction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionServiceServer).CancelAuction(ctx, req.(*CancelAuctionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionService_OfferSecondChance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OfferSecondChanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionServiceServer).OfferSecondChance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuctionService_OfferSecondChance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionServiceServer).OfferSecondChance(ctx, req.(*OfferSecondChanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionService_AcceptSecondChanceOffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecondChanceAnswerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionServiceServer).AcceptSecondChanceOffer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuctionService_AcceptSecondChanceOffer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionServiceServer).AcceptSecondChanceOffer(ctx, req.(*SecondChanceAnswerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionService_DeclineSecondChanceOffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecondChanceAnswerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionServiceServer).DeclineSecondChanceOffer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuctionService_DeclineSecondChanceOffer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionServiceServer).DeclineSecondChanceOffer(ctx, req.(*SecondChanceAnswerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionService_WatchAuction_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchAuctionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AuctionServiceServer).WatchAuction(m, &grpc.GenericServerStream[WatchAuctionRequest, AuctionEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AuctionService_WatchAuctionServer = grpc.ServerStreamingServer[AuctionEvent]
+
+// AuctionService_ServiceDesc is the grpc.ServiceDesc for AuctionService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuctionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "troffee.auction.v1.AuctionService",
+	HandlerType: (*AuctionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateAuction",
+			Handler:    _AuctionService_CreateAuction_Handler,
+		},
+		{
+			MethodName: "GetAuction",
+			Handler:    _AuctionService_GetAuction_Handler,
+		},
+		{
+			MethodName: "ListAuctions",
+			Handler:    _AuctionService_ListAuctions_Handler,
+		},
+		{
+			MethodName: "EndAuction",
+			Handler:    _AuctionService_EndAuction_Handler,
+		},
+		{
+			MethodName: "UpdateAuction",
+			Handler:    _AuctionService_UpdateAuction_Handler,
+		},
+		{
+			MethodName: "CancelAuction",
+			Handler:    _AuctionService_CancelAuction_Handler,
+		},
+		{
+			MethodName: "OfferSecondChance",
+			Handler:    _AuctionService_OfferSecondChance_Handler,
+		},
+		{
+			MethodName: "AcceptSecondChanceOffer",
+			Handler:    _AuctionService_AcceptSecondChanceOffer_Handler,
+		},
+		{
+			MethodName: "DeclineSecondChanceOffer",
+			Handler:    _AuctionService_DeclineSecondChanceOffer_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchAuction",
+			Handler:       _AuctionService_WatchAuction_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "auction/v1/auction.proto",
+}
+
+const (
+	BidService_PlaceBid_FullMethodName    = "/troffee.auction.v1.BidService/PlaceBid"
+	BidService_PlaceMaxBid_FullMethodName = "/troffee.auction.v1.BidService/PlaceMaxBid"
+	BidService_BuyNow_FullMethodName      = "/troffee.auction.v1.BidService/BuyNow"
+	BidService_AcceptPrice_FullMethodName = "/troffee.auction.v1.BidService/AcceptPrice"
+	BidService_RetractBid_FullMethodName  = "/troffee.auction.v1.BidService/RetractBid"
+	BidService_GetBids_FullMethodName     = "/troffee.auction.v1.BidService/GetBids"
+	BidService_GetBestBid_FullMethodName  = "/troffee.auction.v1.BidService/GetBestBid"
+)
+
+// BidServiceClient is the client API for BidService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// BidService places and queries bids
+type BidServiceClient interface {
+	// PlaceBid places a new bid on an auction
+	PlaceBid(ctx context.Context, in *PlaceBidRequest, opts ...grpc.CallOption) (*Bid, error)
+	// PlaceMaxBid stores a hidden maximum bid and bids on the user's behalf up to it
+	PlaceMaxBid(ctx context.Context, in *PlaceMaxBidRequest, opts ...grpc.CallOption) (*ProxyBid, error)
+	// BuyNow ends an auction immediately for the buyer at its buy-now price
+	BuyNow(ctx context.Context, in *BuyNowRequest, opts ...grpc.CallOption) (*AuctionEndResult, error)
+	// AcceptPrice ends a Dutch auction for the buyer at its current price
+	AcceptPrice(ctx context.Context, in *AcceptPriceRequest, opts ...grpc.CallOption) (*AuctionEndResult, error)
+	// RetractBid withdraws an accepted bid on behalf of its bidder or an admin
+	RetractBid(ctx context.Context, in *RetractBidRequest, opts ...grpc.CallOption) (*Retraction, error)
+	// GetBids retrieves the bids of an auction; sealed bids are hidden until it ends
+	GetBids(ctx context.Context, in *GetBidsRequest, opts ...grpc.CallOption) (*GetBidsResponse, error)
+	// GetBestBid retrieves the currently winning bid of an auction
+	GetBestBid(ctx context.Context, in *GetBestBidRequest, opts ...grpc.CallOption) (*Bid, error)
+}
+
+type bidServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBidServiceClient(cc grpc.ClientConnInterface) BidServiceClient {
+	return &bidServiceClient{cc}
+}
+
+func (c *bidServiceClient) PlaceBid(ctx context.Context, in *PlaceBidRequest, opts ...grpc.CallOption) (*Bid, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Bid)
+	err := c.cc.Invoke(ctx, BidService_PlaceBid_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bidServiceClient) PlaceMaxBid(ctx context.Context, in *PlaceMaxBidRequest, opts ...grpc.CallOption) (*ProxyBid, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProxyBid)
+	err := c.cc.Invoke(ctx, BidService_PlaceMaxBid_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bidServiceClient) BuyNow(ctx context.Context, in *BuyNowRequest, opts ...grpc.CallOption) (*AuctionEndResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuctionEndResult)
+	err := c.cc.Invoke(ctx, BidService_BuyNow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bidServiceClient) AcceptPrice(ctx context.Context, in *AcceptPriceRequest, opts ...grpc.CallOption) (*AuctionEndResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuctionEndResult)
+	err := c.cc.Invoke(ctx, BidService_AcceptPrice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bidServiceClient) RetractBid(ctx context.Context, in *RetractBidRequest, opts ...grpc.CallOption) (*Retraction, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Retraction)
+	err := c.cc.Invoke(ctx, BidService_RetractBid_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bidServiceClient) GetBids(ctx context.Context, in *GetBidsRequest, opts ...grpc.CallOption) (*GetBidsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBidsResponse)
+	err := c.cc.Invoke(ctx, BidService_GetBids_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bidServiceClient) GetBestBid(ctx context.Context, in *GetBestBidRequest, opts ...grpc.CallOption) (*Bid, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Bid)
+	err := c.cc.Invoke(ctx, BidService_GetBestBid_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BidServiceServer is the server API for BidService service.
+// All implementations must embed UnimplementedBidServiceServer
+// for forward compatibility.
+//
+// BidService places and queries bids
+type BidServiceServer interface {
+	// PlaceBid places a new bid on an auction
+	PlaceBid(context.Context, *PlaceBidRequest) (*Bid, error)
+	// PlaceMaxBid stores a hidden maximum bid and bids on the user's behalf up to it
+	PlaceMaxBid(context.Context, *PlaceMaxBidRequest) (*ProxyBid, error)
+	// BuyNow ends an auction immediately for the buyer at its buy-now price
+	BuyNow(context.Context, *BuyNowRequest) (*AuctionEndResult, error)
+	// AcceptPrice ends a Dutch auction for the buyer at its current price
+	AcceptPrice(context.Context, *AcceptPriceRequest) (*AuctionEndResult, error)
+	// RetractBid withdraws an accepted bid on behalf of its bidder or an admin
+	RetractBid(context.Context, *RetractBidRequest) (*Retraction, error)
+	// GetBids retrieves the bids of an auction; sealed bids are hidden until it ends
+	GetBids(context.Context, *GetBidsRequest) (*GetBidsResponse, error)
+	// GetBestBid retrieves the currently winning bid of an auction
+	GetBestBid(context.Context, *GetBestBidRequest) (*Bid, error)
+	mustEmbedUnimplementedBidServiceServer()
+}
+
+// UnimplementedBidServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBidServiceServer struct{}
+
+func (UnimplementedBidServiceServer) PlaceBid(context.Context, *PlaceBidRequest) (*Bid, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceBid not implemented")
+}
+func (UnimplementedBidServiceServer) PlaceMaxBid(context.Context, *PlaceMaxBidRequest) (*ProxyBid, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceMaxBid not implemented")
+}
+func (UnimplementedBidServiceServer) BuyNow(context.Context, *BuyNowRequest) (*AuctionEndResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BuyNow not implemented")
+}
+func (UnimplementedBidServiceServer) AcceptPrice(context.Context, *AcceptPriceRequest) (*AuctionEndResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptPrice not implemented")
+}
+func (UnimplementedBidServiceServer) RetractBid(context.Context, *RetractBidRequest) (*Retraction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetractBid not implemented")
+}
+func (UnimplementedBidServiceServer) GetBids(context.Context, *GetBidsRequest) (*GetBidsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBids not implemented")
+}
+func (UnimplementedBidServiceServer) GetBestBid(context.Context, *GetBestBidRequest) (*Bid, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBestBid not implemented")
+}
+func (UnimplementedBidServiceServer) mustEmbedUnimplementedBidServiceServer() {}
+func (UnimplementedBidServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeBidServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BidServiceServer will
+// result in compilation errors.
+type UnsafeBidServiceServer interface {
+	mustEmbedUnimplementedBidServiceServer()
+}
+
+func RegisterBidServiceServer(s grpc.ServiceRegistrar, srv BidServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBidServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BidService_ServiceDesc, srv)
+}
+
+func _BidService_PlaceBid_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceBidRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BidServiceServer).PlaceBid(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BidService_PlaceBid_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BidServiceServer).PlaceBid(ctx, req.(*PlaceBidRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BidService_PlaceMaxBid_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceMaxBidRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BidServiceServer).PlaceMaxBid(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BidService_PlaceMaxBid_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BidServiceServer).PlaceMaxBid(ctx, req.(*PlaceMaxBidRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BidService_BuyNow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuyNowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BidServiceServer).BuyNow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BidService_BuyNow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BidServiceServer).BuyNow(ctx, req.(*BuyNowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BidService_AcceptPrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptPriceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BidServiceServer).AcceptPrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BidService_AcceptPrice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BidServiceServer).AcceptPrice(ctx, req.(*AcceptPriceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BidService_RetractBid_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetractBidRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BidServiceServer).RetractBid(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BidService_RetractBid_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BidServiceServer).RetractBid(ctx, req.(*RetractBidRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BidService_GetBids_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBidsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BidServiceServer).GetBids(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BidService_GetBids_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BidServiceServer).GetBids(ctx, req.(*GetBidsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BidService_GetBestBid_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBestBidRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BidServiceServer).GetBestBid(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BidService_GetBestBid_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BidServiceServer).GetBestBid(ctx, req.(*GetBestBidRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BidService_ServiceDesc is the grpc.ServiceDesc for BidService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BidService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "troffee.auction.v1.BidService",
+	HandlerType: (*BidServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PlaceBid",
+			Handler:    _BidService_PlaceBid_Handler,
+		},
+		{
+			MethodName: "PlaceMaxBid",
+			Handler:    _BidService_PlaceMaxBid_Handler,
+		},
+		{
+			MethodName: "BuyNow",
+			Handler:    _BidService_BuyNow_Handler,
+		},
+		{
+			MethodName: "AcceptPrice",
+			Handler:    _BidService_AcceptPrice_Handler,
+		},
+		{
+			MethodName: "RetractBid",
+			Handler:    _BidService_RetractBid_Handler,
+		},
+		{
+			MethodName: "GetBids",
+			Handler:    _BidService_GetBids_Handler,
+		},
+		{
+			MethodName: "GetBestBid",
+			Handler:    _BidService_GetBestBid_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "auction/v1/auction.proto",
+}
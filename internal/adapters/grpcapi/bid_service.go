@@ -0,0 +1,169 @@
+package grpcapi
+
+import (
+	"context"
+
+	"troffee-auction-service/internal/adapters/grpcapi/auctionv1"
+	"troffee-auction-service/internal/ports/inbound"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// bidServer implements the BidService RPCs on top of inbound.BidService. Requests carry no
+// client ID, so unlike WebSocket bids they are not tied to an auction subscription.
+type bidServer struct {
+	auctionv1.UnimplementedBidServiceServer
+	bidService inbound.BidService
+	logger     zerolog.Logger
+}
+
+func (server *bidServer) PlaceBid(ctx context.Context, req *auctionv1.PlaceBidRequest) (*auctionv1.Bid, error) {
+	auctionID, userID, err := auctionAndUser(req.AuctionId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	amount, err := parseMoney("amount", req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	currency, err := parseCurrency(req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	placed, err := server.bidService.PlaceBid(ctx, inbound.PlaceBidRequest{
+		AuctionID: auctionID,
+		UserID:    userID,
+		Amount:    amount,
+		Currency:  currency,
+		Quantity:  int(req.Quantity),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return bidToProto(placed), nil
+}
+
+func (server *bidServer) PlaceMaxBid(ctx context.Context, req *auctionv1.PlaceMaxBidRequest) (*auctionv1.ProxyBid, error) {
+	auctionID, userID, err := auctionAndUser(req.AuctionId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	maxAmount, err := parseMoney("max_amount", req.MaxAmount)
+	if err != nil {
+		return nil, err
+	}
+	currency, err := parseCurrency(req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy, err := server.bidService.PlaceMaxBid(ctx, inbound.PlaceMaxBidRequest{
+		AuctionID: auctionID,
+		UserID:    userID,
+		MaxAmount: maxAmount,
+		Currency:  currency,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return proxyBidToProto(proxy), nil
+}
+
+func (server *bidServer) BuyNow(ctx context.Context, req *auctionv1.BuyNowRequest) (*auctionv1.AuctionEndResult, error) {
+	auctionID, userID, err := auctionAndUser(req.AuctionId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := server.bidService.BuyNow(ctx, inbound.BuyNowRequest{AuctionID: auctionID, UserID: userID})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return endResultToProto(result), nil
+}
+
+func (server *bidServer) AcceptPrice(ctx context.Context, req *auctionv1.AcceptPriceRequest) (*auctionv1.AuctionEndResult, error) {
+	auctionID, userID, err := auctionAndUser(req.AuctionId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := server.bidService.AcceptPrice(ctx, inbound.AcceptPriceRequest{AuctionID: auctionID, UserID: userID})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return endResultToProto(result), nil
+}
+
+func (server *bidServer) RetractBid(ctx context.Context, req *auctionv1.RetractBidRequest) (*auctionv1.Retraction, error) {
+	auctionID, userID, err := auctionAndUser(req.AuctionId, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	bidID, err := parseID("bid_id", req.BidId)
+	if err != nil {
+		return nil, err
+	}
+
+	retraction, err := server.bidService.RetractBid(ctx, inbound.RetractBidRequest{
+		AuctionID: auctionID,
+		BidID:     bidID,
+		UserID:    userID,
+		Reason:    req.Reason,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return retractionToProto(retraction), nil
+}
+
+func (server *bidServer) GetBids(ctx context.Context, req *auctionv1.GetBidsRequest) (*auctionv1.GetBidsResponse, error) {
+	auctionID, err := parseID("auction_id", req.AuctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	bids, err := server.bidService.GetBids(ctx, auctionID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &auctionv1.GetBidsResponse{}
+	for _, b := range bids {
+		response.Bids = append(response.Bids, bidToProto(b))
+	}
+	return response, nil
+}
+
+func (server *bidServer) GetBestBid(ctx context.Context, req *auctionv1.GetBestBidRequest) (*auctionv1.Bid, error) {
+	auctionID, err := parseID("auction_id", req.AuctionId)
+	if err != nil {
+		return nil, err
+	}
+
+	best, err := server.bidService.GetBestBid(ctx, auctionID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return bidToProto(best), nil
+}
+
+func auctionAndUser(auctionIDValue, userIDValue string) (uuid.UUID, uuid.UUID, error) {
+	auctionID, err := parseID("auction_id", auctionIDValue)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	userID, err := parseID("user_id", userIDValue)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	return auctionID, userID, nil
+}
@@ -0,0 +1,250 @@
+package grpcapi
+
+import (
+	"time"
+
+	"troffee-auction-service/internal/adapters/grpcapi/auctionv1"
+	"troffee-auction-service/internal/domain/auction"
+	"troffee-auction-service/internal/domain/bid"
+	"troffee-auction-service/internal/domain/shared"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// parseID reads a required UUID field
+func parseID(field, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "%s must be a UUID", field)
+	}
+	return id, nil
+}
+
+// parseMoney reads an amount; an empty field is zero
+func parseMoney(field, value string) (shared.Money, error) {
+	if value == "" {
+		return shared.Money{}, nil
+	}
+	amount, err := shared.ParseMoney(value, shared.DefaultCurrency)
+	if err != nil {
+		return shared.Money{}, status.Errorf(codes.InvalidArgument, "%s: %v", field, err)
+	}
+	return amount, nil
+}
+
+// parseOptionalMoney reads an amount whose absence is meaningful
+func parseOptionalMoney(field string, value *string) (*shared.Money, error) {
+	if value == nil {
+		return nil, nil
+	}
+	amount, err := parseMoney(field, *value)
+	if err != nil {
+		return nil, err
+	}
+	return &amount, nil
+}
+
+// parseCurrency reads an optional currency code
+func parseCurrency(value string) (shared.Currency, error) {
+	if value == "" {
+		return "", nil
+	}
+	currency, err := shared.ParseCurrency(value)
+	if err != nil {
+		return "", toStatus(err)
+	}
+	return currency, nil
+}
+
+// formatTime renders a timestamp the way the application services parse it
+func formatTime(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().Format(time.RFC3339)
+}
+
+func optionalString(value *shared.Money) *string {
+	if value == nil {
+		return nil
+	}
+	s := value.String()
+	return &s
+}
+
+func incrementRuleFromProto(rule *auctionv1.IncrementRule) (*auction.IncrementRule, error) {
+	if rule == nil {
+		return nil, nil
+	}
+	flat, err := parseMoney("increment_rule.flat", rule.Flat)
+	if err != nil {
+		return nil, err
+	}
+	result := &auction.IncrementRule{Flat: flat}
+	for _, tier := range rule.Tiers {
+		upTo, err := parseOptionalMoney("increment_rule.tiers.up_to", tier.UpTo)
+		if err != nil {
+			return nil, err
+		}
+		increment, err := parseMoney("increment_rule.tiers.increment", tier.Increment)
+		if err != nil {
+			return nil, err
+		}
+		result.Tiers = append(result.Tiers, auction.IncrementTier{UpTo: upTo, Increment: increment})
+	}
+	return result, nil
+}
+
+func incrementRuleToProto(rule *auction.IncrementRule) *auctionv1.IncrementRule {
+	if rule == nil {
+		return nil
+	}
+	result := &auctionv1.IncrementRule{Flat: rule.Flat.String()}
+	for _, tier := range rule.Tiers {
+		result.Tiers = append(result.Tiers, &auctionv1.IncrementTier{UpTo: optionalString(tier.UpTo), Increment: tier.Increment.String()})
+	}
+	return result
+}
+
+func relistPolicyFromProto(policy *auctionv1.RelistPolicy) *auction.RelistPolicy {
+	if policy == nil {
+		return nil
+	}
+	return &auction.RelistPolicy{
+		MaxRelists:       int(policy.MaxRelists),
+		PriceDropPercent: policy.PriceDropPercent,
+		DurationSeconds:  int(policy.DurationSeconds),
+	}
+}
+
+func relistPolicyToProto(policy *auction.RelistPolicy) *auctionv1.RelistPolicy {
+	if policy == nil {
+		return nil
+	}
+	return &auctionv1.RelistPolicy{
+		MaxRelists:       int32(policy.MaxRelists),
+		PriceDropPercent: policy.PriceDropPercent,
+		DurationSeconds:  int32(policy.DurationSeconds),
+	}
+}
+
+// auctionToProto converts the view of an auction, so the buy-now price is left out once buy-now is withdrawn
+func auctionToProto(a *auction.Auction, buyNowThreshold float64) *auctionv1.Auction {
+	view := auction.NewView(a, buyNowThreshold)
+	// Left empty while a sealed auction is open
+	var currentPrice string
+	if view.CurrentPrice != nil {
+		currentPrice = view.CurrentPrice.String()
+	}
+	result := &auctionv1.Auction{
+		Id:                       a.ID.String(),
+		ItemId:                   a.ItemID.String(),
+		CreatorId:                a.CreatorID.String(),
+		StartTime:                timestamppb.New(a.StartTime),
+		EndTime:                  timestamppb.New(a.EndTime),
+		StartingPrice:            a.StartingPrice.String(),
+		CurrentPrice:             currentPrice,
+		Status:                   string(a.Status),
+		Type:                     string(a.Type),
+		Currency:                 string(a.Currency),
+		SoftCloseSeconds:         int32(a.SoftCloseSeconds),
+		ReserveMet:               !a.PriceHidden() && a.ReserveMet(),
+		BuyNowPrice:              optionalString(view.BuyNowPrice),
+		BuyNowAvailable:          view.BuyNowAvailable,
+		MinNextBid:               optionalString(view.MinNextBid),
+		MaxNextBid:               optionalString(view.MaxNextBid),
+		PriceDropAmount:          a.PriceDropAmount.String(),
+		PriceDropIntervalSeconds: int32(a.PriceDropIntervalSeconds),
+		FloorPrice:               a.FloorPrice.String(),
+		IncrementRule:            incrementRuleToProto(a.IncrementRule),
+		Quantity:                 int32(a.Quantity),
+		Pricing:                  string(a.Pricing),
+		RelistPolicy:             relistPolicyToProto(a.RelistPolicy),
+		RelistCount:              int32(a.RelistCount),
+		CreatedAt:                timestamppb.New(a.CreatedAt),
+		UpdatedAt:                timestamppb.New(a.UpdatedAt),
+	}
+	if a.RelistedFrom != nil {
+		relistedFrom := a.RelistedFrom.String()
+		result.RelistedFrom = &relistedFrom
+	}
+	return result
+}
+
+func bidToProto(b *bid.Bid) *auctionv1.Bid {
+	return &auctionv1.Bid{
+		Id:        b.ID.String(),
+		AuctionId: b.AuctionID.String(),
+		UserId:    b.UserID.String(),
+		Amount:    b.Amount.String(),
+		Quantity:  int32(b.Quantity),
+		Status:    string(b.Status),
+		CreatedAt: timestamppb.New(b.CreatedAt),
+		UpdatedAt: timestamppb.New(b.UpdatedAt),
+	}
+}
+
+func proxyBidToProto(p *bid.ProxyBid) *auctionv1.ProxyBid {
+	return &auctionv1.ProxyBid{
+		Id:        p.ID.String(),
+		AuctionId: p.AuctionID.String(),
+		UserId:    p.UserID.String(),
+		MaxAmount: p.MaxAmount.String(),
+		CreatedAt: timestamppb.New(p.CreatedAt),
+		UpdatedAt: timestamppb.New(p.UpdatedAt),
+	}
+}
+
+func retractionToProto(r *bid.Retraction) *auctionv1.Retraction {
+	return &auctionv1.Retraction{
+		Id:            r.ID.String(),
+		BidId:         r.BidID.String(),
+		AuctionId:     r.AuctionID.String(),
+		RetractedBy:   r.RetractedBy.String(),
+		Reason:        r.Reason,
+		PreviousPrice: r.PreviousPrice.String(),
+		NewPrice:      r.NewPrice.String(),
+		CreatedAt:     timestamppb.New(r.CreatedAt),
+	}
+}
+
+func secondChanceOfferToProto(o *auction.SecondChanceOffer) *auctionv1.SecondChanceOffer {
+	return &auctionv1.SecondChanceOffer{
+		Id:        o.ID.String(),
+		AuctionId: o.AuctionID.String(),
+		BidId:     o.BidID.String(),
+		UserId:    o.UserID.String(),
+		Amount:    o.Amount.String(),
+		Status:    string(o.Status),
+		ExpiresAt: timestamppb.New(o.ExpiresAt),
+		CreatedAt: timestamppb.New(o.CreatedAt),
+		UpdatedAt: timestamppb.New(o.UpdatedAt),
+	}
+}
+
+func endResultToProto(r *shared.AuctionEndResult) *auctionv1.AuctionEndResult {
+	result := &auctionv1.AuctionEndResult{
+		AuctionId: r.AuctionID.String(),
+		Status:    r.Status,
+		Reason:    r.Reason,
+	}
+	for _, winner := range r.Winners {
+		result.Winners = append(result.Winners, &auctionv1.AuctionWinner{
+			UserId:   winner.UserID.String(),
+			BidId:    winner.BidID.String(),
+			Quantity: int32(winner.Quantity),
+			Price:    winner.Price.String(),
+		})
+	}
+	for _, ranking := range r.Rankings {
+		result.Rankings = append(result.Rankings, &auctionv1.BidRanking{
+			Rank:   int32(ranking.Rank),
+			UserId: ranking.UserID.String(),
+			Amount: ranking.Amount.String(),
+		})
+	}
+	return result
+}
@@ -0,0 +1,100 @@
+package grpcapi
+
+import (
+	"errors"
+
+	"troffee-auction-service/internal/domain/shared"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorCodes maps domain errors to gRPC status codes; errors not listed are internal errors
+var errorCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	// Unknown resources
+	{shared.ErrAuctionNotFound, codes.NotFound},
+	{shared.ErrBidNotFound, codes.NotFound},
+	{shared.ErrUserNotFound, codes.NotFound},
+	{shared.ErrItemNotFound, codes.NotFound},
+	{shared.ErrNoBidsFound, codes.NotFound},
+	{shared.ErrSecondChanceOfferNotFound, codes.NotFound},
+
+	// Not allowed for this user
+	{shared.ErrNotAuctionOwner, codes.PermissionDenied},
+	{shared.ErrNotAdmin, codes.PermissionDenied},
+	{shared.ErrNotBidOwner, codes.PermissionDenied},
+	{shared.ErrSealedBidsHidden, codes.PermissionDenied},
+
+	// Lost a race with a concurrent change; the caller may retry
+	{shared.ErrAuctionPriceChanged, codes.Aborted},
+	{shared.ErrAuctionStatusChanged, codes.Aborted},
+	{shared.ErrSecondChanceStatusChanged, codes.Aborted},
+
+	// Not allowed in the current state of the auction, bid or offer
+	{shared.ErrAuctionAlreadyEnded, codes.FailedPrecondition},
+	{shared.ErrAuctionNotAcceptingBids, codes.FailedPrecondition},
+	{shared.ErrAuctionNotStarted, codes.FailedPrecondition},
+	{shared.ErrAuctionNotEnded, codes.FailedPrecondition},
+	{shared.ErrItemAlreadyInAuction, codes.FailedPrecondition},
+	{shared.ErrBuyNowUnavailable, codes.FailedPrecondition},
+	{shared.ErrAuctionNotCancellable, codes.FailedPrecondition},
+	{shared.ErrAuctionNotEditable, codes.FailedPrecondition},
+	{shared.ErrAuctionHasBids, codes.FailedPrecondition},
+	{shared.ErrUnsupportedAuctionType, codes.FailedPrecondition},
+	{shared.ErrSecondChanceUnavailable, codes.FailedPrecondition},
+	{shared.ErrWinnerNotDefaulted, codes.FailedPrecondition},
+	{shared.ErrSecondChanceOfferOpen, codes.FailedPrecondition},
+	{shared.ErrSecondChanceAlreadyAccepted, codes.FailedPrecondition},
+	{shared.ErrNoRunnerUp, codes.FailedPrecondition},
+	{shared.ErrSecondChanceOfferClosed, codes.FailedPrecondition},
+	{shared.ErrSecondChanceOfferExpired, codes.FailedPrecondition},
+	{shared.ErrBidNotRetractable, codes.FailedPrecondition},
+	{shared.ErrRetractionWindowClosed, codes.FailedPrecondition},
+	{shared.ErrBidAmountTooLow, codes.FailedPrecondition},
+	{shared.ErrBidAmountTooHigh, codes.FailedPrecondition},
+	{shared.ErrBidAmountBelowStarting, codes.FailedPrecondition},
+	{shared.ErrBidAmountAboveStarting, codes.FailedPrecondition},
+	{shared.ErrMaxBidNotIncreased, codes.FailedPrecondition},
+	{shared.ErrCreditLimitExceeded, codes.FailedPrecondition},
+	{shared.ErrCreditCurrencyUnconvertible, codes.FailedPrecondition},
+
+	// Invalid requests
+	{shared.ErrInvalidRequest, codes.InvalidArgument},
+	{shared.ErrInvalidMoney, codes.InvalidArgument},
+	{shared.ErrInvalidCurrency, codes.InvalidArgument},
+	{shared.ErrCurrencyMismatch, codes.InvalidArgument},
+	{shared.ErrBidAmountInvalid, codes.InvalidArgument},
+	{shared.ErrInvalidBidQuantity, codes.InvalidArgument},
+	{shared.ErrInvalidTimeFormat, codes.InvalidArgument},
+	{shared.ErrInvalidStartTime, codes.InvalidArgument},
+	{shared.ErrInvalidEndTime, codes.InvalidArgument},
+	{shared.ErrEndTimeInPast, codes.InvalidArgument},
+	{shared.ErrInvalidStartingPrice, codes.InvalidArgument},
+	{shared.ErrInvalidSoftCloseWindow, codes.InvalidArgument},
+	{shared.ErrInvalidReservePrice, codes.InvalidArgument},
+	{shared.ErrInvalidBuyNowPrice, codes.InvalidArgument},
+	{shared.ErrInvalidAuctionType, codes.InvalidArgument},
+	{shared.ErrInvalidPriceDropRule, codes.InvalidArgument},
+	{shared.ErrInvalidQuantity, codes.InvalidArgument},
+	{shared.ErrInvalidPricing, codes.InvalidArgument},
+	{shared.ErrInvalidIncrementRule, codes.InvalidArgument},
+	{shared.ErrInvalidRelistPolicy, codes.InvalidArgument},
+	{shared.ErrCancelReasonRequired, codes.InvalidArgument},
+	{shared.ErrRetractReasonRequired, codes.InvalidArgument},
+}
+
+// toStatus converts a service error into a gRPC status error. Internal errors are not spelled out to the caller.
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	for _, mapping := range errorCodes {
+		if errors.Is(err, mapping.err) {
+			return status.Error(mapping.code, err.Error())
+		}
+	}
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,100 @@
+// Package grpcapi serves the auction and bid services over gRPC for internal services.
+package grpcapi
+
+//go:generate protoc -I ../../../api/proto --go_out=../../.. --go_opt=module=troffee-auction-service --go-grpc_out=../../.. --go-grpc_opt=module=troffee-auction-service auction/v1/auction.proto
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"troffee-auction-service/internal/adapters/grpcapi/auctionv1"
+	"troffee-auction-service/internal/config"
+	"troffee-auction-service/internal/ports/inbound"
+	"troffee-auction-service/internal/ports/outbound"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+)
+
+type Server struct {
+	grpcServer *grpc.Server
+	shutdown   chan struct{} // closed on Stop to end the open WatchAuction streams
+	config     *config.Config
+	logger     zerolog.Logger
+}
+
+type ServerParams struct {
+	Config         *config.Config
+	AuctionService inbound.AuctionService
+	BidService     inbound.BidService
+	Broadcaster    outbound.Broadcaster
+	Logger         zerolog.Logger
+}
+
+// NewServer creates a gRPC server exposing the auction and bid services
+func NewServer(params ServerParams) *Server {
+	logger := params.Logger.With().Str("component", "grpc_server").Logger()
+
+	shutdown := make(chan struct{})
+
+	grpcServer := grpc.NewServer()
+	auctionv1.RegisterAuctionServiceServer(grpcServer, &auctionServer{
+		auctionService:  params.AuctionService,
+		broadcaster:     params.Broadcaster,
+		buyNowThreshold: params.Config.Auction.BuyNowThreshold,
+		shutdown:        shutdown,
+		logger:          logger,
+	})
+	auctionv1.RegisterBidServiceServer(grpcServer, &bidServer{
+		bidService: params.BidService,
+		logger:     logger,
+	})
+
+	return &Server{
+		grpcServer: grpcServer,
+		shutdown:   shutdown,
+		config:     params.Config,
+		logger:     logger,
+	}
+}
+
+// Start starts the gRPC server
+func (s *Server) Start() error {
+	s.logger.Info().Str("port", s.config.Server.GRPCPort).Msg("Starting gRPC server")
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", s.config.Server.GRPCPort))
+	if err != nil {
+		return fmt.Errorf("failed to listen for gRPC: %w", err)
+	}
+
+	if err := s.grpcServer.Serve(listener); err != nil && err != grpc.ErrServerStopped {
+		return fmt.Errorf("failed to start gRPC server: %w", err)
+	}
+
+	return nil
+}
+
+// Stop ends the open WatchAuction streams and waits for other in-flight RPCs to finish,
+// cutting them off when ctx expires
+func (s *Server) Stop(ctx context.Context) error {
+	s.logger.Info().Msg("Stopping gRPC server...")
+
+	close(s.shutdown)
+
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.grpcServer.Stop()
+		return fmt.Errorf("failed to stop gRPC server gracefully: %w", ctx.Err())
+	}
+
+	s.logger.Info().Msg("gRPC server stopped")
+	return nil
+}
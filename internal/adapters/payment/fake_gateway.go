@@ -0,0 +1,67 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"troffee-auction-service/internal/domain/shared"
+	"troffee-auction-service/internal/ports/outbound"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// FakeGateway is an in-process payment gateway for local runs and tests. It approves
+// every charge up to DeclineAbove and answers retried charges of an order with the
+// original result.
+type FakeGateway struct {
+	declineAbove shared.Money
+	charges      map[uuid.UUID]*outbound.PaymentResult // orderID -> successful charge
+	mu           sync.Mutex
+	logger       zerolog.Logger
+}
+type FakeGatewayParams struct {
+	// DeclineAbove declines charges of a higher amount; zero approves every charge
+	DeclineAbove shared.Money
+	Logger       zerolog.Logger
+}
+
+// NewFakeGateway creates a new fake payment gateway
+func NewFakeGateway(params FakeGatewayParams) *FakeGateway {
+	return &FakeGateway{
+		declineAbove: params.DeclineAbove,
+		charges:      make(map[uuid.UUID]*outbound.PaymentResult),
+		logger:       params.Logger.With().Str("component", "fake_payment_gateway").Logger(),
+	}
+}
+
+// Charge approves the payment unless it is above the decline threshold
+func (g *FakeGateway) Charge(ctx context.Context, req outbound.PaymentRequest) (*outbound.PaymentResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if result, ok := g.charges[req.OrderID]; ok {
+		return result, nil
+	}
+
+	if g.declineAbove.IsPositive() && req.Amount.GreaterThan(g.declineAbove) {
+		g.logger.Info().
+			Str("order_id", req.OrderID.String()).
+			Stringer("amount", req.Amount).
+			Msg("Fake payment declined")
+		return nil, shared.ErrPaymentDeclined
+	}
+
+	result := &outbound.PaymentResult{Reference: fmt.Sprintf("fake_%s", uuid.New().String())}
+	g.charges[req.OrderID] = result
+
+	g.logger.Info().
+		Str("order_id", req.OrderID.String()).
+		Str("payer_id", req.PayerID.String()).
+		Stringer("amount", req.Amount).
+		Str("reference", result.Reference).
+		Msg("Fake payment approved")
+
+	return result, nil
+}
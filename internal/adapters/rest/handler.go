@@ -0,0 +1,235 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"troffee-auction-service/internal/domain/auction"
+	"troffee-auction-service/internal/domain/shared"
+	"troffee-auction-service/internal/ports/inbound"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// UserIDHeader identifies the calling user, like the user_id query parameter of the WebSocket endpoint
+const UserIDHeader = "X-User-ID"
+
+// maxBodyBytes caps the size of request bodies
+const maxBodyBytes = 1 << 20
+
+// Handler exposes the auction and bid services as JSON resources over HTTP
+type Handler struct {
+	auctionService  inbound.AuctionService
+	bidService      inbound.BidService
+	buyNowThreshold float64
+	logger          zerolog.Logger
+}
+type HandlerParams struct {
+	AuctionService  inbound.AuctionService
+	BidService      inbound.BidService
+	BuyNowThreshold float64
+	Logger          zerolog.Logger
+}
+
+// NewHandler creates a new HTTP API handler
+func NewHandler(params HandlerParams) *Handler {
+	return &Handler{
+		auctionService:  params.AuctionService,
+		bidService:      params.BidService,
+		buyNowThreshold: params.BuyNowThreshold,
+		logger:          params.Logger.With().Str("component", "rest_handler").Logger(),
+	}
+}
+
+// RegisterRoutes adds the API routes to a mux
+func (handler *Handler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /api/v1/auctions", handler.handleCreateAuction)
+	mux.HandleFunc("GET /api/v1/auctions", handler.handleListAuctions)
+	mux.HandleFunc("GET /api/v1/auctions/{auction_id}", handler.handleGetAuction)
+	mux.HandleFunc("GET /api/v1/auctions/{auction_id}/bids", handler.handleGetBids)
+	mux.HandleFunc("POST /api/v1/auctions/{auction_id}/bids", handler.handlePlaceBid)
+}
+
+// placeBidBody is the body of a bid placed over HTTP; the auction and bidder come from the path and header
+type placeBidBody struct {
+	Amount   shared.Money    `json:"amount"`
+	Currency shared.Currency `json:"currency,omitempty"`
+	Quantity int             `json:"quantity,omitempty"`
+}
+
+// handleCreateAuction creates an auction on behalf of the calling user
+func (handler *Handler) handleCreateAuction(w http.ResponseWriter, r *http.Request) {
+	userID, ok := handler.userID(w, r)
+	if !ok {
+		return
+	}
+
+	var req inbound.CreateAuctionRequest
+	if !handler.decodeBody(w, r, &req) {
+		return
+	}
+	req.CreatorID = userID
+
+	created, err := handler.auctionService.CreateAuction(r.Context(), req)
+	if err != nil {
+		handler.fail(w, r, err)
+		return
+	}
+
+	handler.logger.Info().Str("auction_id", created.ID.String()).Str("user_id", userID.String()).Msg("Auction created over HTTP")
+
+	w.Header().Set("Location", fmt.Sprintf("/api/v1/auctions/%s", created.ID))
+	writeJSON(w, http.StatusCreated, auction.NewView(created, handler.buyNowThreshold))
+}
+
+// handleListAuctions lists auctions, optionally filtered by ?status=, one page at a time
+func (handler *Handler) handleListAuctions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	req := inbound.ListAuctionsRequest{}
+	if status := query.Get("status"); status != "" {
+		auctionStatus := auction.Status(status)
+		req.Status = &auctionStatus
+	}
+
+	var err error
+	if req.Page, err = intParam(query.Get("page")); err != nil {
+		handler.fail(w, r, fmt.Errorf("%w: page must be a number", shared.ErrInvalidRequest))
+		return
+	}
+	if req.PageSize, err = intParam(query.Get("page_size")); err != nil {
+		handler.fail(w, r, fmt.Errorf("%w: page_size must be a number", shared.ErrInvalidRequest))
+		return
+	}
+
+	auctions, err := handler.auctionService.ListAuctions(r.Context(), req)
+	if err != nil {
+		handler.fail(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"auctions": auction.NewViews(auctions, handler.buyNowThreshold),
+		"count":    len(auctions),
+	})
+}
+
+// handleGetAuction returns a single auction
+func (handler *Handler) handleGetAuction(w http.ResponseWriter, r *http.Request) {
+	auctionID, ok := handler.auctionID(w, r)
+	if !ok {
+		return
+	}
+
+	found, err := handler.auctionService.GetAuction(r.Context(), auctionID)
+	if err != nil {
+		handler.fail(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, auction.NewView(found, handler.buyNowThreshold))
+}
+
+// handleGetBids returns the bid history of an auction
+func (handler *Handler) handleGetBids(w http.ResponseWriter, r *http.Request) {
+	auctionID, ok := handler.auctionID(w, r)
+	if !ok {
+		return
+	}
+
+	bids, err := handler.bidService.GetBids(r.Context(), auctionID)
+	if err != nil {
+		handler.fail(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"bids":  bids,
+		"count": len(bids),
+	})
+}
+
+// handlePlaceBid places a bid on behalf of the calling user
+func (handler *Handler) handlePlaceBid(w http.ResponseWriter, r *http.Request) {
+	auctionID, ok := handler.auctionID(w, r)
+	if !ok {
+		return
+	}
+	userID, ok := handler.userID(w, r)
+	if !ok {
+		return
+	}
+
+	var body placeBidBody
+	if !handler.decodeBody(w, r, &body) {
+		return
+	}
+
+	placed, err := handler.bidService.PlaceBid(r.Context(), inbound.PlaceBidRequest{
+		AuctionID: auctionID,
+		UserID:    userID,
+		Amount:    body.Amount,
+		Currency:  body.Currency,
+		Quantity:  body.Quantity,
+	})
+	if err != nil {
+		handler.fail(w, r, err)
+		return
+	}
+
+	handler.logger.Info().Str("bid_id", placed.ID.String()).Str("auction_id", auctionID.String()).Str("user_id", userID.String()).Stringer("amount", placed.Amount).Msg("Bid placed over HTTP")
+
+	w.Header().Set("Location", fmt.Sprintf("/api/v1/auctions/%s/bids", auctionID))
+	writeJSON(w, http.StatusCreated, placed)
+}
+
+// auctionID reads the auction ID from the path, answering 400 when it is not a UUID
+func (handler *Handler) auctionID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	auctionID, err := uuid.Parse(r.PathValue("auction_id"))
+	if err != nil {
+		handler.fail(w, r, shared.ErrAuctionIDRequired)
+		return uuid.Nil, false
+	}
+	return auctionID, true
+}
+
+// userID reads the calling user from the X-User-ID header, answering 400 when it is missing
+func (handler *Handler) userID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(r.Header.Get(UserIDHeader))
+	if err != nil {
+		handler.fail(w, r, fmt.Errorf("%w: %s header must be a user UUID", shared.ErrUserIDRequired, UserIDHeader))
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// decodeBody reads a JSON request body, answering 400 when it is malformed
+func (handler *Handler) decodeBody(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes)).Decode(dst); err != nil {
+		handler.fail(w, r, fmt.Errorf("%w: %v", shared.ErrInvalidRequest, err))
+		return false
+	}
+	return true
+}
+
+// fail answers a request with the problem describing err
+func (handler *Handler) fail(w http.ResponseWriter, r *http.Request, err error) {
+	problem := newProblem(r, err)
+	if problem.Status == http.StatusInternalServerError {
+		handler.logger.Error().Err(err).Str("method", r.Method).Str("path", r.URL.Path).Msg("HTTP request failed")
+	} else {
+		handler.logger.Debug().Err(err).Str("method", r.Method).Str("path", r.URL.Path).Int("status", problem.Status).Msg("HTTP request rejected")
+	}
+	writeProblem(w, problem)
+}
+
+// intParam parses an optional integer query parameter; empty means zero
+func intParam(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
@@ -0,0 +1,133 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"troffee-auction-service/internal/domain/shared"
+)
+
+// Problem is an RFC 7807 problem details body, sent for every failed request
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	// Set when a bid falls short, so clients can retry with a valid amount
+	MinNextBid *shared.Money `json:"min_next_bid,omitempty"`
+	MaxNextBid *shared.Money `json:"max_next_bid,omitempty"`
+}
+
+// errorStatuses maps domain errors to HTTP status codes; errors not listed are internal errors
+var errorStatuses = []struct {
+	err    error
+	status int
+}{
+	// Unknown resources
+	{shared.ErrAuctionNotFound, http.StatusNotFound},
+	{shared.ErrBidNotFound, http.StatusNotFound},
+	{shared.ErrUserNotFound, http.StatusNotFound},
+	{shared.ErrItemNotFound, http.StatusNotFound},
+	{shared.ErrNoBidsFound, http.StatusNotFound},
+
+	// Not allowed for this user
+	{shared.ErrNotAuctionOwner, http.StatusForbidden},
+	{shared.ErrNotAdmin, http.StatusForbidden},
+	{shared.ErrNotBidOwner, http.StatusForbidden},
+	{shared.ErrSealedBidsHidden, http.StatusForbidden},
+
+	// Not allowed in the auction's current state
+	{shared.ErrAuctionAlreadyEnded, http.StatusConflict},
+	{shared.ErrAuctionNotAcceptingBids, http.StatusConflict},
+	{shared.ErrAuctionNotStarted, http.StatusConflict},
+	{shared.ErrItemAlreadyInAuction, http.StatusConflict},
+	{shared.ErrAuctionPriceChanged, http.StatusConflict},
+	{shared.ErrAuctionStatusChanged, http.StatusConflict},
+	{shared.ErrBuyNowUnavailable, http.StatusConflict},
+
+	// Bids the auction rules turn down
+	{shared.ErrBidAmountTooLow, http.StatusUnprocessableEntity},
+	{shared.ErrBidAmountTooHigh, http.StatusUnprocessableEntity},
+	{shared.ErrBidAmountBelowStarting, http.StatusUnprocessableEntity},
+	{shared.ErrBidAmountAboveStarting, http.StatusUnprocessableEntity},
+	{shared.ErrInvalidBidQuantity, http.StatusUnprocessableEntity},
+	{shared.ErrUnsupportedAuctionType, http.StatusUnprocessableEntity},
+	{shared.ErrCreditLimitExceeded, http.StatusUnprocessableEntity},
+	{shared.ErrCreditCurrencyUnconvertible, http.StatusUnprocessableEntity},
+	{shared.ErrCurrencyMismatch, http.StatusUnprocessableEntity},
+
+	// Malformed or invalid requests
+	{shared.ErrInvalidRequest, http.StatusBadRequest},
+	{shared.ErrInvalidMoney, http.StatusBadRequest},
+	{shared.ErrInvalidCurrency, http.StatusBadRequest},
+	{shared.ErrBidAmountInvalid, http.StatusBadRequest},
+	{shared.ErrInvalidTimeFormat, http.StatusBadRequest},
+	{shared.ErrInvalidStartTime, http.StatusBadRequest},
+	{shared.ErrInvalidEndTime, http.StatusBadRequest},
+	{shared.ErrInvalidStartingPrice, http.StatusBadRequest},
+	{shared.ErrInvalidSoftCloseWindow, http.StatusBadRequest},
+	{shared.ErrInvalidReservePrice, http.StatusBadRequest},
+	{shared.ErrInvalidBuyNowPrice, http.StatusBadRequest},
+	{shared.ErrInvalidAuctionType, http.StatusBadRequest},
+	{shared.ErrInvalidPriceDropRule, http.StatusBadRequest},
+	{shared.ErrInvalidQuantity, http.StatusBadRequest},
+	{shared.ErrInvalidPricing, http.StatusBadRequest},
+	{shared.ErrInvalidIncrementRule, http.StatusBadRequest},
+	{shared.ErrInvalidRelistPolicy, http.StatusBadRequest},
+	{shared.ErrAuctionIDRequired, http.StatusBadRequest},
+	{shared.ErrUserIDRequired, http.StatusBadRequest},
+	{shared.ErrInvalidAmount, http.StatusBadRequest},
+}
+
+// statusFor returns the HTTP status code for an error returned by a service
+func statusFor(err error) int {
+	for _, mapping := range errorStatuses {
+		if errors.Is(err, mapping.err) {
+			return mapping.status
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// newProblem describes a failed request. Internal errors are not spelled out to the client.
+func newProblem(r *http.Request, err error) *Problem {
+	status := statusFor(err)
+	problem := &Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   err.Error(),
+		Instance: r.URL.Path,
+	}
+	if status == http.StatusInternalServerError {
+		problem.Detail = ""
+	}
+
+	var minimumBid *shared.MinimumBidError
+	if errors.As(err, &minimumBid) {
+		problem.MinNextBid = &minimumBid.MinNextBid
+	}
+	var maximumBid *shared.MaximumBidError
+	if errors.As(err, &maximumBid) {
+		problem.MaxNextBid = &maximumBid.MaxNextBid
+	}
+
+	return problem
+}
+
+// writeProblem sends a problem+json response
+func writeProblem(w http.ResponseWriter, problem *Problem) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
+
+// writeJSON sends a JSON response
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -7,6 +7,8 @@ import (
 	"sync"
 	"time"
 
+	"troffee-auction-service/internal/domain/auction"
+	"troffee-auction-service/internal/domain/order"
 	"troffee-auction-service/internal/domain/shared"
 	"troffee-auction-service/internal/ports/outbound"
 
@@ -15,36 +17,80 @@ import (
 	"github.com/rs/zerolog"
 )
 
+// Redis sorted sets used as schedules, scored by unix timestamp
+const (
+	expirationsKey = "auction:expirations"
+	startsKey      = "auction:starts"
+	priceDropsKey  = "auction:price_drops"
+	paymentDueKey  = "order:payment_due"
+)
+
 type AuctionEndService interface {
 	EndAuctionForScheduler(ctx context.Context, auctionID uuid.UUID) (*shared.AuctionEndResult, error)
 }
 
+// AuctionStartService opens pending auctions for bidding once their start time is reached
+type AuctionStartService interface {
+	StartAuctionForScheduler(ctx context.Context, auctionID uuid.UUID) (*auction.Auction, error)
+}
+
+// AuctionPriceDropService lowers the price of descending (Dutch) auctions on schedule
+type AuctionPriceDropService interface {
+	// DropPriceForScheduler applies the scheduled price and reports whether it changed
+	DropPriceForScheduler(ctx context.Context, auctionID uuid.UUID) (*auction.Auction, bool, error)
+}
+
+// AuctionRelistService puts auctions that ended unsold back up for sale under their relist policy
+type AuctionRelistService interface {
+	// RelistAuctionForScheduler creates the follow-up auction, or returns shared.ErrAuctionNotRelistable
+	RelistAuctionForScheduler(ctx context.Context, auctionID uuid.UUID) (*auction.Auction, error)
+}
+
+// OrderExpiryService closes orders that were not paid within their payment window
+type OrderExpiryService interface {
+	// ExpireOrderForScheduler expires an unpaid order and tells the buyer, or returns
+	// shared.ErrOrderNotDue or shared.ErrOrderPaymentInProgress when it should be retried later
+	ExpireOrderForScheduler(ctx context.Context, orderID uuid.UUID) (*order.Order, error)
+}
+
 type AuctionScheduler struct {
-	redis          *redis.Client
-	auctionService AuctionEndService
-	broadcaster    outbound.Broadcaster
-	logger         zerolog.Logger
-	ctx            context.Context
-	cancel         context.CancelFunc
-	wg             sync.WaitGroup
+	redis            *redis.Client
+	auctionService   AuctionEndService
+	startService     AuctionStartService
+	priceDropService AuctionPriceDropService
+	relistService    AuctionRelistService
+	orderService     OrderExpiryService
+	broadcaster      outbound.Broadcaster
+	logger           zerolog.Logger
+	ctx              context.Context
+	cancel           context.CancelFunc
+	wg               sync.WaitGroup
 }
 type AuctionSchedulerParams struct {
-	RedisClient    *redis.Client
-	AuctionService AuctionEndService
-	Broadcaster    outbound.Broadcaster
-	Logger         zerolog.Logger
+	RedisClient      *redis.Client
+	AuctionService   AuctionEndService
+	StartService     AuctionStartService
+	PriceDropService AuctionPriceDropService
+	RelistService    AuctionRelistService
+	OrderService     OrderExpiryService
+	Broadcaster      outbound.Broadcaster
+	Logger           zerolog.Logger
 }
 
 func NewAuctionScheduler(params AuctionSchedulerParams) *AuctionScheduler {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	return &AuctionScheduler{
-		redis:          params.RedisClient,
-		auctionService: params.AuctionService,
-		broadcaster:    params.Broadcaster,
-		logger:         params.Logger.With().Str("component", "auction_scheduler").Logger(),
-		ctx:            ctx,
-		cancel:         cancel,
+		redis:            params.RedisClient,
+		auctionService:   params.AuctionService,
+		startService:     params.StartService,
+		priceDropService: params.PriceDropService,
+		relistService:    params.RelistService,
+		orderService:     params.OrderService,
+		broadcaster:      params.Broadcaster,
+		logger:           params.Logger.With().Str("component", "auction_scheduler").Logger(),
+		ctx:              ctx,
+		cancel:           cancel,
 	}
 }
 
@@ -52,7 +98,7 @@ func NewAuctionScheduler(params AuctionSchedulerParams) *AuctionScheduler {
 func (s *AuctionScheduler) ScheduleAuction(auctionID uuid.UUID, endTime time.Time) error {
 	score := float64(endTime.Unix())
 
-	err := s.redis.ZAdd(s.ctx, "auction:expirations", redis.Z{
+	err := s.redis.ZAdd(s.ctx, expirationsKey, redis.Z{
 		Score:  score,
 		Member: auctionID.String(),
 	}).Err()
@@ -70,6 +116,95 @@ func (s *AuctionScheduler) ScheduleAuction(auctionID uuid.UUID, endTime time.Tim
 	return nil
 }
 
+// ScheduleStart adds a pending auction to the start schedule
+func (s *AuctionScheduler) ScheduleStart(auctionID uuid.UUID, startTime time.Time) error {
+	err := s.redis.ZAdd(s.ctx, startsKey, redis.Z{
+		Score:  float64(startTime.Unix()),
+		Member: auctionID.String(),
+	}).Err()
+
+	if err != nil {
+		s.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to schedule auction start")
+		return fmt.Errorf("failed to schedule auction start: %w", err)
+	}
+
+	s.logger.Info().
+		Str("auction_id", auctionID.String()).
+		Time("start_time", startTime).
+		Msg("Auction scheduled to start")
+
+	return nil
+}
+
+// SchedulePriceDrop schedules the next price drop of a Dutch auction
+func (s *AuctionScheduler) SchedulePriceDrop(auctionID uuid.UUID, dropTime time.Time) error {
+	err := s.redis.ZAdd(s.ctx, priceDropsKey, redis.Z{
+		Score:  float64(dropTime.Unix()),
+		Member: auctionID.String(),
+	}).Err()
+
+	if err != nil {
+		s.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to schedule price drop")
+		return fmt.Errorf("failed to schedule price drop: %w", err)
+	}
+
+	s.logger.Debug().
+		Str("auction_id", auctionID.String()).
+		Time("drop_time", dropTime).
+		Msg("Price drop scheduled")
+
+	return nil
+}
+
+// ScheduleOrderExpiry schedules an order to expire if it is still unpaid at its due time
+func (s *AuctionScheduler) ScheduleOrderExpiry(orderID uuid.UUID, dueAt time.Time) error {
+	err := s.redis.ZAdd(s.ctx, paymentDueKey, redis.Z{
+		Score:  float64(dueAt.Unix()),
+		Member: orderID.String(),
+	}).Err()
+
+	if err != nil {
+		s.logger.Error().Err(err).Str("order_id", orderID.String()).Msg("Failed to schedule order expiry")
+		return fmt.Errorf("failed to schedule order expiry: %w", err)
+	}
+
+	s.logger.Debug().
+		Str("order_id", orderID.String()).
+		Time("due_at", dueAt).
+		Msg("Order expiry scheduled")
+
+	return nil
+}
+
+// UnscheduleOrderExpiry removes a paid order from the payment schedule
+func (s *AuctionScheduler) UnscheduleOrderExpiry(orderID uuid.UUID) error {
+	if err := s.redis.ZRem(s.ctx, paymentDueKey, orderID.String()).Err(); err != nil {
+		s.logger.Error().Err(err).Str("order_id", orderID.String()).Msg("Failed to unschedule order expiry")
+		return fmt.Errorf("failed to unschedule order expiry: %w", err)
+	}
+
+	return nil
+}
+
+// UnscheduleAuction removes an auction from the expiration, start and price drop schedules
+func (s *AuctionScheduler) UnscheduleAuction(auctionID uuid.UUID) error {
+	if err := s.redis.ZRem(s.ctx, expirationsKey, auctionID.String()).Err(); err != nil {
+		s.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to unschedule auction")
+		return fmt.Errorf("failed to unschedule auction: %w", err)
+	}
+	if err := s.redis.ZRem(s.ctx, startsKey, auctionID.String()).Err(); err != nil {
+		s.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to unschedule auction start")
+		return fmt.Errorf("failed to unschedule auction start: %w", err)
+	}
+	if err := s.redis.ZRem(s.ctx, priceDropsKey, auctionID.String()).Err(); err != nil {
+		s.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to unschedule price drops")
+		return fmt.Errorf("failed to unschedule price drops: %w", err)
+	}
+
+	s.logger.Info().Str("auction_id", auctionID.String()).Msg("Auction removed from expiration schedule")
+	return nil
+}
+
 // Start begins the scheduler loop
 func (s *AuctionScheduler) Start() {
 	s.logger.Info().Msg("Starting auction scheduler")
@@ -96,7 +231,10 @@ func (s *AuctionScheduler) schedulerLoop() {
 	for {
 		select {
 		case <-ticker.C:
+			s.checkStartingAuctions()
+			s.checkPriceDrops()
 			s.checkExpiredAuctions()
+			s.checkExpiredOrders()
 		case <-s.ctx.Done():
 			s.logger.Info().Msg("Scheduler loop stopped")
 			return
@@ -106,34 +244,156 @@ func (s *AuctionScheduler) schedulerLoop() {
 
 // checkExpiredAuctions finds and processes expired auctions
 func (s *AuctionScheduler) checkExpiredAuctions() {
+	for _, auctionID := range s.dueAuctions(expirationsKey) {
+		// Process auction end
+		go s.endAuction(auctionID)
+	}
+}
+
+// checkStartingAuctions finds pending auctions whose start time has come
+func (s *AuctionScheduler) checkStartingAuctions() {
+	if s.startService == nil {
+		return
+	}
+
+	for _, auctionID := range s.dueAuctions(startsKey) {
+		go s.startAuction(auctionID)
+	}
+}
+
+// checkPriceDrops finds Dutch auctions whose price is due to drop
+func (s *AuctionScheduler) checkPriceDrops() {
+	if s.priceDropService == nil {
+		return
+	}
+
+	for _, auctionID := range s.dueAuctions(priceDropsKey) {
+		go s.dropPrice(auctionID)
+	}
+}
+
+// checkExpiredOrders finds orders whose payment window has closed
+func (s *AuctionScheduler) checkExpiredOrders() {
+	if s.orderService == nil {
+		return
+	}
+
+	for _, orderID := range s.dueAuctions(paymentDueKey) {
+		go s.expireOrder(orderID)
+	}
+}
+
+// dueAuctions returns the auctions in a schedule whose time has come
+func (s *AuctionScheduler) dueAuctions(key string) []uuid.UUID {
 	now := time.Now().Unix()
 
-	// Get expired auctions using ZRANGEBYSCORE
-	expiredAuctions, err := s.redis.ZRangeByScore(s.ctx, "auction:expirations", &redis.ZRangeBy{
+	// Get due auctions using ZRANGEBYSCORE
+	dueAuctions, err := s.redis.ZRangeByScore(s.ctx, key, &redis.ZRangeBy{
 		Min:   "0",
 		Max:   strconv.FormatInt(now, 10),
 		Count: 10, // Process max 10 at a time
 	}).Result()
 
 	if err != nil {
-		s.logger.Error().Err(err).Msg("Failed to get expired auctions")
-		return
+		s.logger.Error().Err(err).Str("schedule", key).Msg("Failed to get due auctions")
+		return nil
 	}
 
-	if len(expiredAuctions) > 0 {
-		s.logger.Debug().Int("count", len(expiredAuctions)).Msg("Found expired auctions")
+	if len(dueAuctions) > 0 {
+		s.logger.Debug().Str("schedule", key).Int("count", len(dueAuctions)).Msg("Found due auctions")
 	}
 
-	for _, auctionIDStr := range expiredAuctions {
+	auctionIDs := make([]uuid.UUID, 0, len(dueAuctions))
+	for _, auctionIDStr := range dueAuctions {
 		auctionID, err := uuid.Parse(auctionIDStr)
 		if err != nil {
 			s.logger.Error().Err(err).Str("auction_id", auctionIDStr).Msg("Invalid auction ID")
 			continue
 		}
+		auctionIDs = append(auctionIDs, auctionID)
+	}
 
-		// Process auction end
-		go s.endAuction(auctionID)
+	return auctionIDs
+}
+
+// dropPrice lowers a Dutch auction to its scheduled price and schedules the next drop
+func (s *AuctionScheduler) dropPrice(auctionID uuid.UUID) {
+	auction, dropped, err := s.priceDropService.DropPriceForScheduler(s.ctx, auctionID)
+	if err != nil {
+		s.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to drop auction price")
+		s.redis.ZRem(s.ctx, priceDropsKey, auctionID.String())
+		return
+	}
+
+	eventData := map[string]interface{}{
+		"auction_id":    auctionID.String(),
+		"current_price": auction.CurrentPrice,
+		"floor_price":   auction.FloorPrice,
+	}
+
+	if nextDrop, ok := auction.NextPriceDropAt(time.Now()); ok {
+		if err := s.SchedulePriceDrop(auctionID, nextDrop); err != nil {
+			return
+		}
+		eventData["next_price_drop_at"] = nextDrop.Format(time.RFC3339)
+	} else {
+		// The floor price was reached
+		s.redis.ZRem(s.ctx, priceDropsKey, auctionID.String())
 	}
+
+	if !dropped {
+		return
+	}
+
+	event := outbound.Event{
+		Type:      outbound.EventTypePriceDropped,
+		AuctionID: auctionID,
+		Data:      eventData,
+		Timestamp: time.Now().Unix(),
+	}
+
+	if err := s.broadcaster.Publish(s.ctx, auctionID, event); err != nil {
+		s.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to broadcast price drop event")
+	}
+
+	s.logger.Info().
+		Str("auction_id", auctionID.String()).
+		Stringer("current_price", auction.CurrentPrice).
+		Msg("Auction price dropped")
+}
+
+// startAuction opens a pending auction for bidding and announces it
+func (s *AuctionScheduler) startAuction(auctionID uuid.UUID) {
+	auction, err := s.startService.StartAuctionForScheduler(s.ctx, auctionID)
+	if err == shared.ErrAuctionNotStarted {
+		// Picked up early within the same second, retry on the next tick
+		return
+	}
+	defer s.redis.ZRem(s.ctx, startsKey, auctionID.String())
+
+	if err != nil {
+		s.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to start auction")
+		return
+	}
+
+	event := outbound.Event{
+		Type:      outbound.EventTypeAuctionStarted,
+		AuctionID: auctionID,
+		Data: map[string]interface{}{
+			"auction_id":    auctionID.String(),
+			"status":        string(auction.Status),
+			"start_time":    auction.StartTime.Format(time.RFC3339),
+			"end_time":      auction.EndTime.Format(time.RFC3339),
+			"current_price": auction.CurrentPrice,
+		},
+		Timestamp: time.Now().Unix(),
+	}
+
+	if err := s.broadcaster.Publish(s.ctx, auctionID, event); err != nil {
+		s.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to broadcast auction start event")
+	}
+
+	s.logger.Info().Str("auction_id", auctionID.String()).Msg("Auction started")
 }
 
 // endAuction processes the end of an auction
@@ -142,7 +402,12 @@ func (s *AuctionScheduler) endAuction(auctionID uuid.UUID) {
 
 	// End the auction
 	result, err := s.auctionService.EndAuctionForScheduler(s.ctx, auctionID)
-	defer s.redis.ZRem(s.ctx, "auction:expirations", auctionID.String())
+	if err == shared.ErrAuctionNotEnded {
+		// The auction was extended by a late bid and is already rescheduled
+		s.logger.Info().Str("auction_id", auctionID.String()).Msg("Auction was extended, keeping it scheduled")
+		return
+	}
+	defer s.redis.ZRem(s.ctx, expirationsKey, auctionID.String())
 
 	if err != nil {
 		s.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to end auction")
@@ -150,37 +415,90 @@ func (s *AuctionScheduler) endAuction(auctionID uuid.UUID) {
 	}
 
 	// Broadcast result
-	eventData := map[string]interface{}{
-		"auction_id": auctionID.String(),
-		"status":     result.Status,
+	event := outbound.NewAuctionEndedEvent(result)
+
+	// Broadcast to all subscribers
+	if err := s.broadcaster.Publish(s.ctx, auctionID, event); err != nil {
+		s.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to broadcast auction end event")
+	}
+
+	logger := s.logger.Info().Str("auction_id", auctionID.String())
+
+	if len(result.Winners) == 1 {
+		logger = logger.Str("winner_id", result.Winners[0].UserID.String()).Stringer("final_price", result.Winners[0].Price)
+	} else if result.HasWinner() {
+		logger = logger.Int("winners", len(result.Winners)).Int("units_sold", result.UnitsSold())
+	}
+
+	logger.Msg("Auction ended successfully")
+
+	// Failures returned above; an auction without winners had no bids or missed its reserve
+	if !result.HasWinner() {
+		s.relistAuction(auctionID)
+	}
+}
+
+// relistAuction creates the follow-up of an unsold auction and points its watchers to it
+func (s *AuctionScheduler) relistAuction(auctionID uuid.UUID) {
+	if s.relistService == nil {
+		return
 	}
-	if result.WinnerID != nil {
-		eventData["winner_id"] = result.WinnerID.String()
+
+	relisted, err := s.relistService.RelistAuctionForScheduler(s.ctx, auctionID)
+	if err == shared.ErrAuctionNotRelistable {
+		return
 	}
-	if result.FinalPrice != nil {
-		eventData["final_price"] = *result.FinalPrice
+	if err != nil {
+		s.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to relist auction")
+		return
 	}
 
 	event := outbound.Event{
-		Type:      outbound.EventTypeAuctionEnded,
+		Type:      outbound.EventTypeAuctionRelisted,
 		AuctionID: auctionID,
-		Data:      eventData,
+		Data: map[string]interface{}{
+			"auction_id":     auctionID.String(),
+			"new_auction_id": relisted.ID.String(),
+			"relist_count":   relisted.RelistCount,
+			"starting_price": relisted.StartingPrice,
+			"start_time":     relisted.StartTime.Format(time.RFC3339),
+			"end_time":       relisted.EndTime.Format(time.RFC3339),
+		},
 		Timestamp: time.Now().Unix(),
 	}
 
-	// Broadcast to all subscribers
 	if err := s.broadcaster.Publish(s.ctx, auctionID, event); err != nil {
-		s.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to broadcast auction end event")
+		s.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to broadcast auction relisted event")
 	}
 
-	logger := s.logger.Info().Str("auction_id", auctionID.String())
+	s.logger.Info().
+		Str("auction_id", auctionID.String()).
+		Str("new_auction_id", relisted.ID.String()).
+		Int("relist_count", relisted.RelistCount).
+		Msg("Auction relisted")
+}
+
+// expireOrder closes an unpaid order; the order service tells the buyer
+func (s *AuctionScheduler) expireOrder(orderID uuid.UUID) {
+	expired, err := s.orderService.ExpireOrderForScheduler(s.ctx, orderID)
+	if err == shared.ErrOrderNotDue || err == shared.ErrOrderPaymentInProgress {
+		// Picked up early within the same second, or a payment is in flight: retry on the next tick
+		return
+	}
+	defer s.redis.ZRem(s.ctx, paymentDueKey, orderID.String())
 
-	if result.WinnerID != nil {
-		logger = logger.Str("winner_id", result.WinnerID.String())
+	if err == shared.ErrOrderNotPayable {
+		// Paid or already closed in the meantime
+		return
 	}
-	if result.FinalPrice != nil {
-		logger = logger.Float64("final_price", *result.FinalPrice)
+	if err != nil {
+		s.logger.Error().Err(err).Str("order_id", orderID.String()).Msg("Failed to expire order")
+		return
 	}
 
-	logger.Msg("Auction ended successfully")
+	s.logger.Info().
+		Str("order_id", orderID.String()).
+		Str("auction_id", expired.AuctionID.String()).
+		Str("buyer_id", expired.BuyerID.String()).
+		Msg("Unpaid order expired")
 }
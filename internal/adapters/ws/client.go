@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 	"troffee-auction-service/internal/config"
+	"troffee-auction-service/internal/domain/shared"
 
 	"github.com/alitto/pond"
 	"github.com/google/uuid"
@@ -23,13 +24,22 @@ type WsClient struct {
 	handler    *WsHandler
 	workerPool *pond.WorkerPool
 	stopped    bool
+	currency   shared.Currency               // preferred display currency; empty shows auction currencies only
+	followed   map[uuid.UUID]shared.Currency // currency of each subscribed auction
 	mu         sync.Mutex
 	logger     zerolog.Logger
+
+	// seqMu orders auction events sent to the client; lastSeqs holds the sequence number
+	// of the last event queued per auction, so that replayed events are not sent twice
+	// and lost ones are noticed
+	seqMu    sync.Mutex
+	lastSeqs map[uuid.UUID]int64
 }
 type WsClientParams struct {
-	UserID  uuid.UUID
-	Conn    *websocket.Conn
-	Handler *WsHandler
+	UserID   uuid.UUID
+	Conn     *websocket.Conn
+	Handler  *WsHandler
+	Currency shared.Currency
 }
 
 // NewClient creates a new WebSocket client
@@ -51,6 +61,9 @@ func NewClient(params WsClientParams) *WsClient {
 		cancel:     cancel,
 		handler:    params.Handler,
 		workerPool: pool,
+		currency:   params.Currency,
+		followed:   make(map[uuid.UUID]shared.Currency),
+		lastSeqs:   make(map[uuid.UUID]int64),
 		logger:     zerolog.New(nil).With().Str("client_id", uuid.New().String()).Str("user_id", params.UserID.String()).Logger(),
 	}
 
@@ -82,6 +95,50 @@ func (client *WsClient) Stop() {
 	}
 }
 
+// Currency returns the client's preferred display currency
+func (client *WsClient) Currency() shared.Currency {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return client.currency
+}
+
+// SetCurrency changes the client's preferred display currency
+func (client *WsClient) SetCurrency(currency shared.Currency) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	client.currency = currency
+}
+
+// Follow records the currency of an auction the client subscribed to
+func (client *WsClient) Follow(auctionID uuid.UUID, currency shared.Currency) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	client.followed[auctionID] = currency
+}
+
+// Unfollow forgets an auction the client unsubscribed from
+func (client *WsClient) Unfollow(auctionID uuid.UUID) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	delete(client.followed, auctionID)
+}
+
+// FollowedCurrencies returns the currencies of the auctions the client subscribed to
+func (client *WsClient) FollowedCurrencies() []shared.Currency {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	seen := make(map[shared.Currency]bool, len(client.followed))
+	currencies := make([]shared.Currency, 0, len(client.followed))
+	for _, currency := range client.followed {
+		if !seen[currency] {
+			seen[currency] = true
+			currencies = append(currencies, currency)
+		}
+	}
+	return currencies
+}
+
 // Send sends a message to the client
 func (client *WsClient) Send(msg *ServerMessage) error {
 	client.mu.Lock()
@@ -105,6 +162,12 @@ func (client *WsClient) Send(msg *ServerMessage) error {
 	}
 }
 
+// Reply sends the direct response to a client message, echoing its request ID
+func (client *WsClient) Reply(request *ClientMessage, msg *ServerMessage) error {
+	msg.RequestID = request.RequestID
+	return client.Send(msg)
+}
+
 func (client *WsClient) messageSender() {
 	for {
 		select {
@@ -148,10 +211,13 @@ func (client *WsClient) messageReceiver() {
 			client.logger.Debug().Str("message", string(message)).Msg("Message received from client")
 
 			client.workerPool.Submit(func() {
-				if err := client.handleMessage(message); err != nil {
+				if msg, err := client.handleMessage(message); err != nil {
 					client.logger.Error().Err(err).Msg("Failed to handle message in worker pool")
 					errorMsg := NewErrorMessage(err.Error(), nil)
-					client.sendMessage(errorMsg)
+					if msg != nil {
+						errorMsg.RequestID = msg.RequestID
+					}
+					client.Send(errorMsg)
 				}
 			})
 		}
@@ -162,24 +228,26 @@ func (client *WsClient) sendMessage(msg *ServerMessage) error {
 	return client.conn.WriteJSON(msg)
 }
 
-func (client *WsClient) handleMessage(data []byte) error {
+// handleMessage parses and handles a client message; the parsed message is returned
+// alongside any error so that the error reply can carry its request ID
+func (client *WsClient) handleMessage(data []byte) (*ClientMessage, error) {
 	msg, err := ParseClientMessage(data)
 	if err != nil {
-		return fmt.Errorf("invalid message format: %w", err)
+		return nil, fmt.Errorf("invalid message format: %w", err)
 	}
 
 	// Validate the message
 	if err := msg.Validate(); err != nil {
-		return fmt.Errorf("message validation failed: %w", err)
+		return msg, fmt.Errorf("message validation failed: %w", err)
 	}
 
 	if msg.Type == MessageTypePing {
 		response := NewServerMessage(MessageTypePong)
-		return client.Send(response)
+		return msg, client.Reply(msg, response)
 	}
 
 	if client.handler != nil {
-		return client.handler.HandleClientMessage(client, msg)
+		return msg, client.handler.HandleClientMessage(client, msg)
 	}
-	return fmt.Errorf("handler not available")
+	return msg, fmt.Errorf("handler not available")
 }
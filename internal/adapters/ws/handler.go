@@ -2,6 +2,8 @@ package ws
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"sync"
 	"time"
@@ -18,34 +20,65 @@ import (
 
 // WsHandler manages WebSocket connections and message routing
 type WsHandler struct {
-	clients        map[string]*WsClient // clientID -> Client
-	clientsMu      sync.RWMutex
-	eventChannels  map[string]chan outbound.Event // clientID -> local event channel
-	channelsMu     sync.RWMutex
-	upgrader       websocket.Upgrader
-	auctionService inbound.AuctionService
-	bidService     inbound.BidService
-	broadcaster    outbound.Broadcaster
-	logger         zerolog.Logger
+	clients         map[string]*WsClient // clientID -> Client
+	clientsMu       sync.RWMutex
+	eventChannels   map[string]chan outbound.Event // clientID -> local event channel
+	channelsMu      sync.RWMutex
+	upgrader        websocket.Upgrader
+	auctionService  inbound.AuctionService
+	bidService      inbound.BidService
+	settlement      inbound.SettlementService
+	ledger          inbound.LedgerService
+	userService     inbound.UserService
+	broadcaster     outbound.Broadcaster
+	fxRates         outbound.FXRateProvider
+	buyNowThreshold float64
+	sseKeepAlive    time.Duration
+	streamsDone     chan struct{}
+	streamsOnce     sync.Once
+	logger          zerolog.Logger
 }
 type WsHandlerParams struct {
-	Upgrader       websocket.Upgrader
-	AuctionService inbound.AuctionService
-	BidService     inbound.BidService
-	Broadcaster    outbound.Broadcaster
-	Logger         zerolog.Logger
+	Upgrader          websocket.Upgrader
+	AuctionService    inbound.AuctionService
+	BidService        inbound.BidService
+	SettlementService inbound.SettlementService
+	LedgerService     inbound.LedgerService
+	UserService       inbound.UserService
+	Broadcaster       outbound.Broadcaster
+	// FXRates converts bid_placed amounts into each client's preferred currency; nil disables conversions
+	FXRates         outbound.FXRateProvider
+	BuyNowThreshold float64
+	// SSEKeepAlive is how often idle event streams get a keepalive comment
+	SSEKeepAlive time.Duration
+	Logger       zerolog.Logger
 }
 
+// defaultSSEKeepAlive applies when no keepalive interval is configured
+const defaultSSEKeepAlive = 15 * time.Second
+
 // NewHandler creates a new WebSocket handler
 func NewHandler(params WsHandlerParams) *WsHandler {
+	sseKeepAlive := params.SSEKeepAlive
+	if sseKeepAlive <= 0 {
+		sseKeepAlive = defaultSSEKeepAlive
+	}
+
 	return &WsHandler{
-		clients:        make(map[string]*WsClient),
-		eventChannels:  make(map[string]chan outbound.Event),
-		upgrader:       params.Upgrader,
-		auctionService: params.AuctionService,
-		bidService:     params.BidService,
-		broadcaster:    params.Broadcaster,
-		logger:         params.Logger.With().Str("component", "ws_handler").Logger(),
+		clients:         make(map[string]*WsClient),
+		eventChannels:   make(map[string]chan outbound.Event),
+		upgrader:        params.Upgrader,
+		auctionService:  params.AuctionService,
+		bidService:      params.BidService,
+		settlement:      params.SettlementService,
+		ledger:          params.LedgerService,
+		userService:     params.UserService,
+		broadcaster:     params.Broadcaster,
+		fxRates:         params.FXRates,
+		buyNowThreshold: params.BuyNowThreshold,
+		sseKeepAlive:    sseKeepAlive,
+		streamsDone:     make(chan struct{}),
+		logger:          params.Logger.With().Str("component", "ws_handler").Logger(),
 	}
 }
 
@@ -63,6 +96,13 @@ func (handler *WsHandler) HandleWebSocket(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// The preferred display currency may be chosen up front and changed later with set_currency
+	currency, err := shared.ParseCurrency(r.URL.Query().Get("currency"))
+	if err != nil && r.URL.Query().Has("currency") {
+		http.Error(w, "invalid currency", http.StatusBadRequest)
+		return
+	}
+
 	// Upgrade HTTP connection to WebSocket
 	conn, err := handler.upgrader.Upgrade(w, r, nil)
 	if err != nil {
@@ -72,16 +112,22 @@ func (handler *WsHandler) HandleWebSocket(w http.ResponseWriter, r *http.Request
 
 	// Create new client
 	client := NewClient(WsClientParams{
-		UserID:  userID,
-		Conn:    conn,
-		Handler: handler,
+		UserID:   userID,
+		Conn:     conn,
+		Handler:  handler,
+		Currency: currency,
 	})
 
 	// Register client
 	handler.registerClient(client)
 
 	// Create local event channel for this client
-	handler.createEventChannel(client.id)
+	eventChan := handler.createEventChannel(client.id)
+
+	// Events addressed to the user, such as second-chance offers, arrive without subscribing to an auction
+	if err := handler.broadcaster.SubscribeUser(context.Background(), userID, client.id, eventChan); err != nil {
+		handler.logger.Error().Err(err).Str("client_id", client.id).Str("user_id", userID.String()).Msg("Failed to subscribe client to user events")
+	}
 
 	// Start client message handling
 	client.Start()
@@ -140,15 +186,17 @@ func (handler *WsHandler) registerClient(client *WsClient) {
 }
 
 func (handler *WsHandler) unregisterClient(client *WsClient) {
+	// Drop the auction and user subscriptions first, so nothing is delivered to the event channel once it is closed
+	if err := handler.broadcaster.UnsubscribeClient(context.Background(), client.id); err != nil {
+		handler.logger.Error().Err(err).Str("client_id", client.id).Msg("Failed to unsubscribe disconnected client")
+	}
+
 	handler.clientsMu.Lock()
 	defer handler.clientsMu.Unlock()
 
 	// Remove client from registry
 	delete(handler.clients, client.id)
 
-	// Note: Redis broadcaster handles subscription cleanup automatically
-	// No need to manually unsubscribe - Redis will clean up when client disconnects
-
 	// Stop the client
 	client.Stop()
 
@@ -174,11 +222,16 @@ func (handler *WsHandler) listenForClientEvents(client *WsClient) {
 	// Listen for events and forward to WebSocket
 	for {
 		select {
-		case event := <-eventChan:
+		case event, ok := <-eventChan:
+			if !ok {
+				return
+			}
 			handler.logger.Debug().Str("client_id", client.id).Msg("Received event for client")
-			wsMessage := handler.convertEventToMessage(event)
+			client.seqMu.Lock()
+			err := handler.forwardEvent(client, event)
+			client.seqMu.Unlock()
 
-			if err := client.Send(wsMessage); err != nil {
+			if err != nil {
 				handler.logger.Error().
 					Err(err).Str("client_id", client.id).Msg("Failed to send event to WebSocket client")
 			} else {
@@ -193,6 +246,77 @@ func (handler *WsHandler) listenForClientEvents(client *WsClient) {
 	}
 }
 
+// forwardEvent sends a broadcast event to a client unless a replay already sent it. When events
+// were lost since the last one sent, the missed ones are replayed first. The caller holds client.seqMu.
+func (handler *WsHandler) forwardEvent(client *WsClient, event outbound.Event) error {
+	if event.Seq > 0 {
+		last := client.lastSeqs[event.AuctionID]
+		if event.Seq <= last {
+			return nil
+		}
+		if last > 0 && event.Seq > last+1 {
+			return handler.catchUp(client, event, last)
+		}
+	}
+	return handler.sendEvent(client, event)
+}
+
+// sendEvent sends an event to a client and, once it was queued, records it as sent.
+// The caller holds client.seqMu.
+func (handler *WsHandler) sendEvent(client *WsClient, event outbound.Event) error {
+	wsMessage := handler.convertEventToMessage(event)
+	if wsMessage.Type == MessageTypeBidPlaced {
+		wsMessage = handler.withConvertedAmounts(wsMessage, client.Currency())
+	}
+	if err := client.Send(wsMessage); err != nil {
+		return err
+	}
+
+	if event.Seq > 0 {
+		client.lastSeqs[event.AuctionID] = event.Seq
+	}
+	return nil
+}
+
+// catchUp replays the events of an auction a client missed after lastSeq, up to and including event,
+// or sends the auction's current state when they are no longer retained. The caller holds client.seqMu.
+func (handler *WsHandler) catchUp(client *WsClient, event outbound.Event, lastSeq int64) error {
+	ctx := context.Background()
+
+	handler.logger.Warn().Str("client_id", client.id).Str("auction_id", event.AuctionID.String()).
+		Int64("last_seq", lastSeq).Int64("seq", event.Seq).Msg("Client missed auction events, catching up")
+
+	missed, err := handler.broadcaster.EventsSince(ctx, event.AuctionID, lastSeq)
+	if err != nil {
+		if !errors.Is(err, shared.ErrEventsExpired) {
+			handler.logger.Error().Err(err).Str("client_id", client.id).Str("auction_id", event.AuctionID.String()).Msg("Failed to replay missed events")
+		}
+
+		auction, err := handler.auctionService.GetAuction(ctx, event.AuctionID)
+		if err != nil {
+			return err
+		}
+		if err := client.Send(handler.createAuctionResponse(auction, MessageTypeAuctionSnapshot, &event.AuctionID)); err != nil {
+			return err
+		}
+		client.lastSeqs[event.AuctionID] = event.Seq
+		return nil
+	}
+
+	for _, m := range missed {
+		if m.Seq <= client.lastSeqs[event.AuctionID] {
+			continue
+		}
+		if err := handler.sendEvent(client, m); err != nil {
+			return err
+		}
+	}
+	if event.Seq > client.lastSeqs[event.AuctionID] {
+		return handler.sendEvent(client, event)
+	}
+	return nil
+}
+
 func (handler *WsHandler) HandleClientMessage(client *WsClient, msg *ClientMessage) error {
 	switch msg.Type {
 	case MessageTypeSubscribe:
@@ -204,45 +328,223 @@ func (handler *WsHandler) HandleClientMessage(client *WsClient, msg *ClientMessa
 	case MessageTypePlaceBid:
 		return handler.handlePlaceBid(client, msg)
 
+	case MessageTypePlaceMaxBid:
+		return handler.handlePlaceMaxBid(client, msg)
+
+	case MessageTypeBuyNow:
+		return handler.handleBuyNow(client, msg)
+
+	case MessageTypeAcceptPrice:
+		return handler.handleAcceptPrice(client, msg)
+
 	case MessageTypeCreateAuction:
 		return handler.handleCreateAuction(client, msg)
 
+	case MessageTypeRetractBid:
+		return handler.handleRetractBid(client, msg)
+
+	case MessageTypeCancelAuction:
+		return handler.handleCancelAuction(client, msg)
+
+	case MessageTypeOfferSecondChance:
+		return handler.handleOfferSecondChance(client, msg)
+
+	case MessageTypeAcceptSecondChance:
+		return handler.handleAcceptSecondChance(client, msg)
+
+	case MessageTypeDeclineSecondChance:
+		return handler.handleDeclineSecondChance(client, msg)
+
+	case MessageTypeUpdateAuction:
+		return handler.handleUpdateAuction(client, msg)
+
+	case MessageTypePayOrder:
+		return handler.handlePayOrder(client, msg)
+
+	case MessageTypeGetBalance:
+		return handler.handleGetBalance(client, msg)
+
+	case MessageTypeGetStatement:
+		return handler.handleGetStatement(client, msg)
+
+	case MessageTypeSetCreditLimit:
+		return handler.handleSetCreditLimit(client, msg)
+
 	case MessageTypeGetAuction:
 		return handler.handleGetAuction(client, msg)
 
 	case MessageTypeListAuctions:
 		return handler.handleListAuctions(client, msg)
 
+	case MessageTypeSetCurrency:
+		return handler.handleSetCurrency(client, msg)
+
 	default:
 		handler.logger.Warn().Str("client_id", client.id).Str("message_type", string(msg.Type)).Msg("Unknown message type from client")
 		return shared.ErrUnknownMessageType
 	}
 }
 
+// withConvertedAmounts adds the bid amounts in the client's preferred currency to a bid_placed
+// message, under "converted" keyed by that currency. The message is copied since every
+// subscriber may prefer a different currency; without a rate the message is sent as is.
+func (handler *WsHandler) withConvertedAmounts(msg *ServerMessage, preferred shared.Currency) *ServerMessage {
+	if handler.fxRates == nil || preferred == "" {
+		return msg
+	}
+
+	code, _ := msg.Data["currency"].(string)
+	currency, err := shared.ParseCurrency(code)
+	if err != nil {
+		currency = shared.DefaultCurrency
+	}
+	if currency == preferred {
+		return msg
+	}
+
+	rate, err := handler.fxRates.Rate(context.Background(), currency, preferred)
+	if err != nil {
+		handler.logger.Debug().Err(err).Str("from", string(currency)).Str("to", string(preferred)).Msg("No exchange rate for bid conversion")
+		return msg
+	}
+
+	converted := make(map[string]interface{})
+	for _, key := range []string{"amount", "min_next_bid", "max_next_bid"} {
+		if amount, ok := moneyField(msg.Data, key); ok {
+			converted[key] = amount.Convert(rate, preferred)
+		}
+	}
+
+	data := make(map[string]interface{}, len(msg.Data)+1)
+	for key, value := range msg.Data {
+		data[key] = value
+	}
+	data["converted"] = map[shared.Currency]interface{}{preferred: converted}
+
+	copied := *msg
+	copied.Data = data
+	return &copied
+}
+
 func (handler *WsHandler) convertEventToMessage(event outbound.Event) *ServerMessage {
+	var msg *ServerMessage
 	switch event.Type {
 	case outbound.EventTypeBidPlaced:
-		return &ServerMessage{
+		msg = &ServerMessage{
 			Type:      MessageTypeBidPlaced,
 			AuctionID: &event.AuctionID,
 			Data:      event.Data,
 			Timestamp: event.Timestamp,
 		}
+	case outbound.EventTypeBidRetracted:
+		msg = &ServerMessage{
+			Type:      MessageTypeBidRetracted,
+			AuctionID: &event.AuctionID,
+			Data:      event.Data,
+			Timestamp: event.Timestamp,
+		}
+	case outbound.EventTypeAuctionStarted:
+		msg = &ServerMessage{
+			Type:      MessageTypeAuctionStarted,
+			AuctionID: &event.AuctionID,
+			Data:      event.Data,
+			Timestamp: event.Timestamp,
+		}
+	case outbound.EventTypeAuctionCancelled:
+		msg = &ServerMessage{
+			Type:      MessageTypeAuctionCancelled,
+			AuctionID: &event.AuctionID,
+			Data:      event.Data,
+			Timestamp: event.Timestamp,
+		}
+	case outbound.EventTypeAuctionUpdated:
+		msg = &ServerMessage{
+			Type:      MessageTypeAuctionUpdated,
+			AuctionID: &event.AuctionID,
+			Data:      event.Data,
+			Timestamp: event.Timestamp,
+		}
+	case outbound.EventTypeAuctionRelisted:
+		msg = &ServerMessage{
+			Type:      MessageTypeAuctionRelisted,
+			AuctionID: &event.AuctionID,
+			Data:      event.Data,
+			Timestamp: event.Timestamp,
+		}
 	case outbound.EventTypeAuctionEnded:
-		return &ServerMessage{
+		msg = &ServerMessage{
 			Type:      MessageTypeAuctionEnded,
 			AuctionID: &event.AuctionID,
 			Data:      event.Data,
 			Timestamp: event.Timestamp,
 		}
+	case outbound.EventTypeAuctionExtended:
+		msg = &ServerMessage{
+			Type:      MessageTypeAuctionExtended,
+			AuctionID: &event.AuctionID,
+			Data:      event.Data,
+			Timestamp: event.Timestamp,
+		}
+	case outbound.EventTypePriceDropped:
+		msg = &ServerMessage{
+			Type:      MessageTypePriceDropped,
+			AuctionID: &event.AuctionID,
+			Data:      event.Data,
+			Timestamp: event.Timestamp,
+		}
+	case outbound.EventTypeSecondChanceOffered:
+		msg = &ServerMessage{
+			Type:      MessageTypeSecondChanceOffer,
+			AuctionID: &event.AuctionID,
+			Data:      event.Data,
+			Timestamp: event.Timestamp,
+		}
+	case outbound.EventTypeSecondChanceAnswered:
+		msg = &ServerMessage{
+			Type:      MessageTypeSecondChanceAnswered,
+			AuctionID: &event.AuctionID,
+			Data:      event.Data,
+			Timestamp: event.Timestamp,
+		}
+	case outbound.EventTypeOrderCreated:
+		msg = &ServerMessage{
+			Type:      MessageTypeOrderCreated,
+			AuctionID: &event.AuctionID,
+			Data:      event.Data,
+			Timestamp: event.Timestamp,
+		}
+	case outbound.EventTypeOrderPaid:
+		msg = &ServerMessage{
+			Type:      MessageTypeOrderPaid,
+			AuctionID: &event.AuctionID,
+			Data:      event.Data,
+			Timestamp: event.Timestamp,
+		}
+	case outbound.EventTypeOrderPaymentFailed:
+		msg = &ServerMessage{
+			Type:      MessageTypeOrderPaymentFailed,
+			AuctionID: &event.AuctionID,
+			Data:      event.Data,
+			Timestamp: event.Timestamp,
+		}
+	case outbound.EventTypeOrderExpired:
+		msg = &ServerMessage{
+			Type:      MessageTypeOrderExpired,
+			AuctionID: &event.AuctionID,
+			Data:      event.Data,
+			Timestamp: event.Timestamp,
+		}
 	default:
-		return &ServerMessage{
+		msg = &ServerMessage{
 			Type:      MessageTypeAuctionUpdate,
 			AuctionID: &event.AuctionID,
 			Data:      event.Data,
 			Timestamp: event.Timestamp,
 		}
 	}
+
+	msg.Seq = event.Seq
+	return msg
 }
 
 // GetConnectedClients returns the number of connected clients
@@ -265,18 +567,87 @@ func (handler *WsHandler) handleSubscribe(client *WsClient, msg *ClientMessage)
 		return shared.ErrClientEventChannelNotFound
 	}
 
+	sinceSeq, resume, err := sinceSeqField(msg.Data)
+	if err != nil {
+		return err
+	}
+
+	auction, err := handler.auctionService.GetAuction(ctx, *msg.AuctionID)
+	if err != nil {
+		return err
+	}
+
+	// Live events wait until the missed ones have been replayed
+	client.seqMu.Lock()
+	defer client.seqMu.Unlock()
+
 	// Subscribe to broadcaster with the local event channel
 	if err := handler.broadcaster.Subscribe(ctx, *msg.AuctionID, client.id, eventChan); err != nil {
 		handler.logger.Error().Err(err).Str("client_id", client.id).Str("auction_id", msg.AuctionID.String()).Msg("Failed to subscribe to auction")
 		return err
 	}
 
+	client.Follow(auction.ID, auction.Currency)
+	// A resuming client has seen everything up to sinceSeq; later gaps are caught up from there
+	if resume && sinceSeq > client.lastSeqs[auction.ID] {
+		client.lastSeqs[auction.ID] = sinceSeq
+	}
+
 	response := NewServerMessage(MessageTypeAuctionUpdate)
 	response.AuctionID = msg.AuctionID
 	response.Data["status"] = "subscribed"
+	if preferred := client.Currency(); preferred != "" {
+		response.Data["conversions_available"] = handler.conversionsAvailable([]shared.Currency{auction.Currency}, preferred)
+	}
 
 	handler.logger.Info().Str("client_id", client.id).Str("auction_id", msg.AuctionID.String()).Msg("Client subscribed to auction")
-	return client.Send(response)
+	if !resume {
+		return client.Reply(msg, response)
+	}
+
+	// A reconnecting client catches up on the events it missed, or gets the current state
+	// of the auction when they are no longer retained
+	missed, err := handler.broadcaster.EventsSince(ctx, *msg.AuctionID, sinceSeq)
+	if err != nil {
+		if !errors.Is(err, shared.ErrEventsExpired) {
+			handler.logger.Error().Err(err).Str("client_id", client.id).Str("auction_id", msg.AuctionID.String()).Msg("Failed to replay missed events")
+		}
+		// The snapshot is the new baseline; its sequence number is unknown
+		delete(client.lastSeqs, auction.ID)
+		response.Data["snapshot"] = true
+		if err := client.Reply(msg, response); err != nil {
+			return err
+		}
+		return handler.sendSnapshot(client, msg)
+	}
+
+	response.Data["replayed"] = len(missed)
+	if err := client.Reply(msg, response); err != nil {
+		return err
+	}
+	for _, event := range missed {
+		if event.Seq <= client.lastSeqs[event.AuctionID] {
+			continue
+		}
+		if err := handler.sendEvent(client, event); err != nil {
+			return err
+		}
+	}
+
+	handler.logger.Info().Str("client_id", client.id).Str("auction_id", msg.AuctionID.String()).Int64("since_seq", sinceSeq).Int("replayed", len(missed)).Msg("Replayed missed events")
+	return nil
+}
+
+// sendSnapshot sends a resuming client the current state of an auction in place of the events it missed
+func (handler *WsHandler) sendSnapshot(client *WsClient, msg *ClientMessage) error {
+	auction, err := handler.auctionService.GetAuction(context.Background(), *msg.AuctionID)
+	if err != nil {
+		errorMsg := NewErrorMessage(err.Error(), msg.AuctionID)
+		return client.Reply(msg, errorMsg)
+	}
+
+	response := handler.createAuctionResponse(auction, MessageTypeAuctionSnapshot, msg.AuctionID)
+	return client.Reply(msg, response)
 }
 
 // handleUnsubscribe handles unsubscription from auction events
@@ -291,6 +662,12 @@ func (handler *WsHandler) handleUnsubscribe(client *WsClient, msg *ClientMessage
 	if err := handler.broadcaster.Unsubscribe(ctx, *msg.AuctionID, client.id); err != nil {
 		return err
 	}
+	client.Unfollow(*msg.AuctionID)
+
+	// A later subscription starts without a baseline, so no gap is assumed across it
+	client.seqMu.Lock()
+	delete(client.lastSeqs, *msg.AuctionID)
+	client.seqMu.Unlock()
 
 	// Send confirmation
 	response := NewServerMessage(MessageTypeAuctionUpdate)
@@ -298,7 +675,7 @@ func (handler *WsHandler) handleUnsubscribe(client *WsClient, msg *ClientMessage
 	response.Data["status"] = "unsubscribed"
 
 	handler.logger.Info().Str("client_id", client.id).Str("auction_id", msg.AuctionID.String()).Msg("Client unsubscribed from auction")
-	return client.Send(response)
+	return client.Reply(msg, response)
 }
 
 // handlePlaceBid handles bid placement
@@ -307,11 +684,21 @@ func (handler *WsHandler) handlePlaceBid(client *WsClient, msg *ClientMessage) e
 		return shared.ErrAuctionIDRequired
 	}
 
-	amount, ok := msg.Data["amount"].(float64)
+	amount, ok := moneyField(msg.Data, "amount")
 	if !ok {
 		return shared.ErrInvalidAmount
 	}
 
+	currency, err := currencyField(msg.Data, "currency")
+	if err != nil {
+		return err
+	}
+
+	quantity := 0
+	if quantityVal, ok := msg.Data["quantity"].(float64); ok {
+		quantity = int(quantityVal)
+	}
+
 	ctx := context.Background()
 
 	// Create bid request
@@ -320,6 +707,8 @@ func (handler *WsHandler) handlePlaceBid(client *WsClient, msg *ClientMessage) e
 		UserID:    client.userID,
 		ClientID:  client.id,
 		Amount:    amount,
+		Currency:  currency,
+		Quantity:  quantity,
 	}
 
 	// Place bid through application service
@@ -327,12 +716,448 @@ func (handler *WsHandler) handlePlaceBid(client *WsClient, msg *ClientMessage) e
 	if err != nil {
 		// Send error message back to client
 		errorMsg := NewErrorMessage(err.Error(), msg.AuctionID)
-		return client.Send(errorMsg)
+		return client.Reply(msg, errorMsg)
 	}
 
-	handler.logger.Info().Str("bid_id", bid.ID.String()).Str("auction_id", msg.AuctionID.String()).Str("user_id", client.userID.String()).Float64("amount", amount).Msg("Bid placed successfully")
+	handler.logger.Info().Str("bid_id", bid.ID.String()).Str("auction_id", msg.AuctionID.String()).Str("user_id", client.userID.String()).Stringer("amount", amount).Msg("Bid placed successfully")
 
-	return nil
+	// Sealed bids are never broadcast, so only the bidder gets a confirmation
+	auction, err := handler.auctionService.GetAuction(ctx, *msg.AuctionID)
+	if err == nil && auction.IsSealed() {
+		response := NewServerMessage(MessageTypeBidSubmitted)
+		response.AuctionID = msg.AuctionID
+		response.Data["bid_id"] = bid.ID
+		response.Data["amount"] = bid.Amount
+		return client.Reply(msg, response)
+	}
+
+	// Open bids are also broadcast as bid_placed, but the bidder gets an ack tied to its request
+	response := NewServerMessage(MessageTypeBidAccepted)
+	response.AuctionID = msg.AuctionID
+	response.Data["bid_id"] = bid.ID
+	response.Data["amount"] = bid.Amount
+	response.Data["currency"] = bid.Amount.Currency()
+	response.Data["quantity"] = bid.Quantity
+	return client.Reply(msg, response)
+}
+
+// handlePlaceMaxBid handles proxy (maximum) bid placement
+func (handler *WsHandler) handlePlaceMaxBid(client *WsClient, msg *ClientMessage) error {
+	if msg.AuctionID == nil {
+		return shared.ErrAuctionIDRequired
+	}
+
+	maxAmount, ok := moneyField(msg.Data, "max_amount")
+	if !ok {
+		return shared.ErrInvalidMaxAmount
+	}
+
+	currency, err := currencyField(msg.Data, "currency")
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	maxBidRequest := inbound.PlaceMaxBidRequest{
+		AuctionID: *msg.AuctionID,
+		UserID:    client.userID,
+		ClientID:  client.id,
+		MaxAmount: maxAmount,
+		Currency:  currency,
+	}
+
+	proxy, err := handler.bidService.PlaceMaxBid(ctx, maxBidRequest)
+	if err != nil {
+		errorMsg := NewErrorMessage(err.Error(), msg.AuctionID)
+		return client.Reply(msg, errorMsg)
+	}
+
+	// The maximum is only ever confirmed to the bidder who placed it
+	response := NewServerMessage(MessageTypeMaxBidPlaced)
+	response.AuctionID = msg.AuctionID
+	response.Data["max_amount"] = proxy.MaxAmount
+
+	handler.logger.Info().Str("auction_id", msg.AuctionID.String()).Str("user_id", client.userID.String()).Msg("Max bid placed successfully")
+	return client.Reply(msg, response)
+}
+
+// handleRetractBid handles withdrawing a bid within the retraction window (or by an admin)
+func (handler *WsHandler) handleRetractBid(client *WsClient, msg *ClientMessage) error {
+	if msg.AuctionID == nil {
+		return shared.ErrAuctionIDRequired
+	}
+
+	bidIDStr, ok := msg.Data["bid_id"].(string)
+	if !ok {
+		return shared.ErrBidIDRequired
+	}
+
+	bidID, err := uuid.Parse(bidIDStr)
+	if err != nil {
+		return shared.ErrBidIDRequired
+	}
+
+	ctx := context.Background()
+
+	reason, _ := msg.Data["reason"].(string)
+
+	retractRequest := inbound.RetractBidRequest{
+		AuctionID: *msg.AuctionID,
+		BidID:     bidID,
+		UserID:    client.userID,
+		Reason:    reason,
+	}
+
+	retraction, err := handler.bidService.RetractBid(ctx, retractRequest)
+	if err != nil {
+		errorMsg := NewErrorMessage(err.Error(), msg.AuctionID)
+		return client.Reply(msg, errorMsg)
+	}
+
+	// Subscribers are notified by the bid_retracted broadcast; the retractor may not be subscribed
+	response := NewServerMessage(MessageTypeBidRetracted)
+	response.AuctionID = msg.AuctionID
+	response.Data["bid_id"] = retraction.BidID
+	response.Data["retracted_by"] = retraction.RetractedBy
+	response.Data["reason"] = retraction.Reason
+	response.Data["previous_price"] = retraction.PreviousPrice
+	response.Data["current_price"] = retraction.NewPrice
+
+	handler.logger.Info().Str("auction_id", msg.AuctionID.String()).Str("bid_id", bidID.String()).Str("user_id", client.userID.String()).Msg("Bid retracted")
+	return client.Reply(msg, response)
+}
+
+// handleBuyNow handles buying an auction at its buy-now price
+func (handler *WsHandler) handleBuyNow(client *WsClient, msg *ClientMessage) error {
+	if msg.AuctionID == nil {
+		return shared.ErrAuctionIDRequired
+	}
+
+	ctx := context.Background()
+
+	buyNowRequest := inbound.BuyNowRequest{
+		AuctionID: *msg.AuctionID,
+		UserID:    client.userID,
+		ClientID:  client.id,
+	}
+
+	result, err := handler.bidService.BuyNow(ctx, buyNowRequest)
+	if err != nil {
+		errorMsg := NewErrorMessage(err.Error(), msg.AuctionID)
+		return client.Reply(msg, errorMsg)
+	}
+
+	// Subscribers learn of the sale from the auction_ended broadcast; the buyer may not be subscribed
+	response := handler.convertEventToMessage(outbound.NewAuctionEndedEvent(result))
+
+	handler.logger.Info().Str("auction_id", result.AuctionID.String()).Str("user_id", client.userID.String()).Msg("Auction bought now")
+	return client.Reply(msg, response)
+}
+
+// handleAcceptPrice handles accepting the current price of a Dutch auction
+func (handler *WsHandler) handleAcceptPrice(client *WsClient, msg *ClientMessage) error {
+	if msg.AuctionID == nil {
+		return shared.ErrAuctionIDRequired
+	}
+
+	ctx := context.Background()
+
+	acceptPriceRequest := inbound.AcceptPriceRequest{
+		AuctionID: *msg.AuctionID,
+		UserID:    client.userID,
+		ClientID:  client.id,
+	}
+
+	result, err := handler.bidService.AcceptPrice(ctx, acceptPriceRequest)
+	if err != nil {
+		errorMsg := NewErrorMessage(err.Error(), msg.AuctionID)
+		return client.Reply(msg, errorMsg)
+	}
+
+	// Subscribers learn of the sale from the auction_ended broadcast; the buyer may not be subscribed
+	response := handler.convertEventToMessage(outbound.NewAuctionEndedEvent(result))
+
+	handler.logger.Info().Str("auction_id", result.AuctionID.String()).Str("user_id", client.userID.String()).Msg("Auction price accepted")
+	return client.Reply(msg, response)
+}
+
+// handleOfferSecondChance handles a seller offering the item to the runner-up after the winner defaulted
+func (handler *WsHandler) handleOfferSecondChance(client *WsClient, msg *ClientMessage) error {
+	if msg.AuctionID == nil {
+		return shared.ErrAuctionIDRequired
+	}
+
+	ctx := context.Background()
+
+	offerRequest := inbound.SecondChanceOfferRequest{
+		AuctionID: *msg.AuctionID,
+		UserID:    client.userID,
+	}
+
+	// The runner-up is notified through their user channel
+	offer, err := handler.auctionService.OfferSecondChance(ctx, offerRequest)
+	if err != nil {
+		errorMsg := NewErrorMessage(err.Error(), msg.AuctionID)
+		return client.Reply(msg, errorMsg)
+	}
+
+	response := NewServerMessage(MessageTypeSecondChanceOffer)
+	response.AuctionID = msg.AuctionID
+	response.Data["offer_id"] = offer.ID.String()
+	response.Data["user_id"] = offer.UserID.String()
+	response.Data["amount"] = offer.Amount
+	response.Data["status"] = string(offer.Status)
+	response.Data["expires_at"] = offer.ExpiresAt.Format(time.RFC3339)
+
+	handler.logger.Info().Str("auction_id", msg.AuctionID.String()).Str("offer_id", offer.ID.String()).Msg("Second-chance offer made")
+	return client.Reply(msg, response)
+}
+
+// handleAcceptSecondChance handles a runner-up buying the item at the offered price
+func (handler *WsHandler) handleAcceptSecondChance(client *WsClient, msg *ClientMessage) error {
+	offerID, err := parseOfferID(msg)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	answerRequest := inbound.SecondChanceAnswerRequest{
+		OfferID: offerID,
+		UserID:  client.userID,
+	}
+
+	result, err := handler.auctionService.AcceptSecondChanceOffer(ctx, answerRequest)
+	if err != nil {
+		errorMsg := NewErrorMessage(err.Error(), msg.AuctionID)
+		return client.Reply(msg, errorMsg)
+	}
+
+	// The buyer may no longer be subscribed to the auction, so the sale is confirmed directly
+	response := handler.convertEventToMessage(outbound.NewAuctionEndedEvent(result))
+
+	handler.logger.Info().Str("auction_id", result.AuctionID.String()).Str("offer_id", offerID.String()).Msg("Second-chance offer accepted")
+	return client.Reply(msg, response)
+}
+
+// handleDeclineSecondChance handles a runner-up turning a second-chance offer down
+func (handler *WsHandler) handleDeclineSecondChance(client *WsClient, msg *ClientMessage) error {
+	offerID, err := parseOfferID(msg)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	answerRequest := inbound.SecondChanceAnswerRequest{
+		OfferID: offerID,
+		UserID:  client.userID,
+	}
+
+	offer, err := handler.auctionService.DeclineSecondChanceOffer(ctx, answerRequest)
+	if err != nil {
+		errorMsg := NewErrorMessage(err.Error(), msg.AuctionID)
+		return client.Reply(msg, errorMsg)
+	}
+
+	response := NewServerMessage(MessageTypeSecondChanceAnswered)
+	response.AuctionID = &offer.AuctionID
+	response.Data["offer_id"] = offer.ID.String()
+	response.Data["status"] = string(offer.Status)
+
+	handler.logger.Info().Str("auction_id", offer.AuctionID.String()).Str("offer_id", offerID.String()).Msg("Second-chance offer declined")
+	return client.Reply(msg, response)
+}
+
+// parseOfferID reads the offer_id of a second-chance answer
+func parseOfferID(msg *ClientMessage) (uuid.UUID, error) {
+	offerIDStr, ok := msg.Data["offer_id"].(string)
+	if !ok {
+		return uuid.Nil, shared.ErrOfferIDRequired
+	}
+
+	offerID, err := uuid.Parse(offerIDStr)
+	if err != nil {
+		return uuid.Nil, shared.ErrOfferIDRequired
+	}
+
+	return offerID, nil
+}
+
+// handlePayOrder handles a winner paying for their order
+func (handler *WsHandler) handlePayOrder(client *WsClient, msg *ClientMessage) error {
+	if handler.settlement == nil {
+		errorMsg := NewErrorMessage(shared.ErrUnknownMessageType.Error(), msg.AuctionID)
+		return client.Reply(msg, errorMsg)
+	}
+
+	orderIDStr, _ := msg.Data["order_id"].(string)
+	orderID, err := uuid.Parse(orderIDStr)
+	if err != nil {
+		return shared.ErrOrderIDRequired
+	}
+
+	ctx := context.Background()
+
+	payRequest := inbound.PayOrderRequest{
+		OrderID: orderID,
+		UserID:  client.userID,
+	}
+
+	paid, err := handler.settlement.PayOrder(ctx, payRequest)
+	if err != nil {
+		errorMsg := NewErrorMessage(err.Error(), msg.AuctionID)
+		return client.Reply(msg, errorMsg)
+	}
+
+	response := NewServerMessage(MessageTypeOrderPaid)
+	response.AuctionID = &paid.AuctionID
+	response.Data["order_id"] = paid.ID.String()
+	response.Data["amount"] = paid.Amount
+	response.Data["status"] = string(paid.Status)
+	response.Data["payment_reference"] = paid.PaymentReference
+
+	handler.logger.Info().Str("order_id", paid.ID.String()).Str("user_id", client.userID.String()).Msg("Order paid")
+	return client.Reply(msg, response)
+}
+
+// handleGetBalance handles a user asking for the balance of their account
+func (handler *WsHandler) handleGetBalance(client *WsClient, msg *ClientMessage) error {
+	if handler.ledger == nil {
+		errorMsg := NewErrorMessage(shared.ErrUnknownMessageType.Error(), nil)
+		return client.Reply(msg, errorMsg)
+	}
+
+	ctx := context.Background()
+
+	balances, err := handler.ledger.GetBalances(ctx, client.userID)
+	if err != nil {
+		errorMsg := NewErrorMessage(err.Error(), nil)
+		return client.Reply(msg, errorMsg)
+	}
+
+	response := NewServerMessage(MessageTypeBalance)
+	response.Data["user_id"] = client.userID.String()
+	response.Data["balances"] = balances
+
+	return client.Reply(msg, response)
+}
+
+// handleSetCurrency handles a client choosing the currency bid amounts are converted into
+func (handler *WsHandler) handleSetCurrency(client *WsClient, msg *ClientMessage) error {
+	currency, err := currencyField(msg.Data, "currency")
+	if err != nil || currency == "" {
+		return shared.ErrInvalidCurrency
+	}
+
+	client.SetCurrency(currency)
+
+	response := NewServerMessage(MessageTypeCurrencyUpdated)
+	response.Data["currency"] = currency
+	// Tell the client up front when amounts of the auctions it follows cannot be shown in its currency
+	currencies := client.FollowedCurrencies()
+	if len(currencies) == 0 {
+		currencies = []shared.Currency{shared.DefaultCurrency}
+	}
+	response.Data["conversions_available"] = handler.conversionsAvailable(currencies, currency)
+
+	return client.Reply(msg, response)
+}
+
+// conversionsAvailable returns true if amounts in every one of currencies can be shown in preferred
+func (handler *WsHandler) conversionsAvailable(currencies []shared.Currency, preferred shared.Currency) bool {
+	for _, currency := range currencies {
+		if currency == preferred {
+			continue
+		}
+		if handler.fxRates == nil {
+			return false
+		}
+		if _, err := handler.fxRates.Rate(context.Background(), currency, preferred); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// handleGetStatement handles a user asking for the entries of their account
+func (handler *WsHandler) handleGetStatement(client *WsClient, msg *ClientMessage) error {
+	if handler.ledger == nil {
+		errorMsg := NewErrorMessage(shared.ErrUnknownMessageType.Error(), nil)
+		return client.Reply(msg, errorMsg)
+	}
+
+	ctx := context.Background()
+
+	limit := 20
+	if limitVal, ok := msg.Data["limit"].(float64); ok && limitVal > 0 {
+		limit = int(limitVal)
+	}
+
+	offset := 0
+	if offsetVal, ok := msg.Data["offset"].(float64); ok {
+		offset = int(offsetVal)
+	}
+
+	statementRequest := inbound.StatementRequest{
+		UserID:   client.userID,
+		Page:     offset/limit + 1, // Convert offset to page
+		PageSize: limit,
+	}
+
+	entries, err := handler.ledger.GetStatement(ctx, statementRequest)
+	if err != nil {
+		errorMsg := NewErrorMessage(err.Error(), nil)
+		return client.Reply(msg, errorMsg)
+	}
+
+	response := NewServerMessage(MessageTypeStatement)
+	response.Data["user_id"] = client.userID.String()
+	response.Data["entries"] = entries
+	response.Data["count"] = len(entries)
+
+	return client.Reply(msg, response)
+}
+
+// handleSetCreditLimit handles an admin setting a user's credit limit
+func (handler *WsHandler) handleSetCreditLimit(client *WsClient, msg *ClientMessage) error {
+	if handler.userService == nil {
+		errorMsg := NewErrorMessage(shared.ErrUnknownMessageType.Error(), nil)
+		return client.Reply(msg, errorMsg)
+	}
+
+	userIDStr, _ := msg.Data["user_id"].(string)
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return shared.ErrUserIDRequired
+	}
+
+	// A missing or null credit_limit removes the limit
+	var creditLimit *shared.Money
+	if limitVal, ok := moneyField(msg.Data, "credit_limit"); ok {
+		creditLimit = &limitVal
+	}
+
+	ctx := context.Background()
+
+	limitRequest := inbound.SetCreditLimitRequest{
+		AdminID:     client.userID,
+		UserID:      userID,
+		CreditLimit: creditLimit,
+	}
+
+	user, err := handler.userService.SetCreditLimit(ctx, limitRequest)
+	if err != nil {
+		errorMsg := NewErrorMessage(err.Error(), nil)
+		return client.Reply(msg, errorMsg)
+	}
+
+	response := NewServerMessage(MessageTypeCreditLimitUpdated)
+	response.Data["user_id"] = user.ID.String()
+	response.Data["credit_limit"] = user.CreditLimit
+
+	return client.Reply(msg, response)
 }
 
 // handleCreateAuction handles auction creation
@@ -360,32 +1185,172 @@ func (handler *WsHandler) handleCreateAuction(client *WsClient, msg *ClientMessa
 		return shared.ErrEndTimeRequired
 	}
 
-	startingPrice, ok := msg.Data["starting_price"].(float64)
+	startingPrice, ok := moneyField(msg.Data, "starting_price")
 	if !ok {
 		return shared.ErrStartingPriceRequired
 	}
 
+	var reservePrice *shared.Money
+	if reserveVal, ok := moneyField(msg.Data, "reserve_price"); ok {
+		reservePrice = &reserveVal
+	}
+
+	var buyNowPrice *shared.Money
+	if buyNowVal, ok := moneyField(msg.Data, "buy_now_price"); ok {
+		buyNowPrice = &buyNowVal
+	}
+
+	softCloseSeconds := 0
+	if softCloseVal, ok := msg.Data["soft_close_seconds"].(float64); ok {
+		softCloseSeconds = int(softCloseVal)
+	}
+
+	var auctionType auction.Type
+	if typeVal, ok := msg.Data["type"].(string); ok {
+		auctionType = auction.Type(typeVal)
+	}
+
+	currency, err := currencyField(msg.Data, "currency")
+	if err != nil {
+		return err
+	}
+
+	priceDropAmount, _ := moneyField(msg.Data, "price_drop_amount")
+	floorPrice, _ := moneyField(msg.Data, "floor_price")
+
+	var incrementRule *auction.IncrementRule
+	if ruleVal, ok := msg.Data["increment_rule"]; ok && ruleVal != nil {
+		ruleJSON, err := json.Marshal(ruleVal)
+		if err != nil {
+			return shared.ErrInvalidIncrementRule
+		}
+		if err := json.Unmarshal(ruleJSON, &incrementRule); err != nil {
+			return shared.ErrInvalidIncrementRule
+		}
+	}
+
+	var relistPolicy *auction.RelistPolicy
+	if policyVal, ok := msg.Data["relist_policy"]; ok && policyVal != nil {
+		policyJSON, err := json.Marshal(policyVal)
+		if err != nil {
+			return shared.ErrInvalidRelistPolicy
+		}
+		if err := json.Unmarshal(policyJSON, &relistPolicy); err != nil {
+			return shared.ErrInvalidRelistPolicy
+		}
+	}
+
+	quantity := 0
+	if quantityVal, ok := msg.Data["quantity"].(float64); ok {
+		quantity = int(quantityVal)
+	}
+
+	var pricing auction.Pricing
+	if pricingVal, ok := msg.Data["pricing"].(string); ok {
+		pricing = auction.Pricing(pricingVal)
+	}
+
+	priceDropIntervalSeconds := 0
+	if intervalVal, ok := msg.Data["price_drop_interval_seconds"].(float64); ok {
+		priceDropIntervalSeconds = int(intervalVal)
+	}
+
 	// Create auction request
 	auctionRequest := inbound.CreateAuctionRequest{
-		ItemID:        itemID,
-		CreatorID:     client.userID,
-		StartTime:     startTimeStr,
-		EndTime:       endTimeStr,
-		StartingPrice: startingPrice,
+		ItemID:           itemID,
+		CreatorID:        client.userID,
+		StartTime:        startTimeStr,
+		EndTime:          endTimeStr,
+		StartingPrice:    startingPrice,
+		Currency:         currency,
+		SoftCloseSeconds: softCloseSeconds,
+		ReservePrice:     reservePrice,
+		BuyNowPrice:      buyNowPrice,
+		IncrementRule:    incrementRule,
+		Quantity:         quantity,
+		Pricing:          pricing,
+
+		Type:                     auctionType,
+		PriceDropAmount:          priceDropAmount,
+		PriceDropIntervalSeconds: priceDropIntervalSeconds,
+		FloorPrice:               floorPrice,
+
+		RelistPolicy: relistPolicy,
 	}
 
 	// Create auction through application service
 	auction, err := handler.auctionService.CreateAuction(ctx, auctionRequest)
 	if err != nil {
 		errorMsg := NewErrorMessage(err.Error(), nil)
-		return client.Send(errorMsg)
+		return client.Reply(msg, errorMsg)
 	}
 
 	// Send success response
 	response := handler.createAuctionResponse(auction, MessageTypeAuctionCreated, nil)
 
 	handler.logger.Info().Str("auction_id", auction.ID.String()).Str("user_id", client.userID.String()).Msg("Auction created successfully")
-	return client.Send(response)
+	return client.Reply(msg, response)
+}
+
+// handleCancelAuction handles cancelling an auction by its creator or an admin
+func (handler *WsHandler) handleCancelAuction(client *WsClient, msg *ClientMessage) error {
+	if msg.AuctionID == nil {
+		return shared.ErrAuctionIDRequired
+	}
+
+	ctx := context.Background()
+
+	reason, _ := msg.Data["reason"].(string)
+
+	cancelRequest := inbound.CancelAuctionRequest{
+		AuctionID: *msg.AuctionID,
+		UserID:    client.userID,
+		Reason:    reason,
+	}
+
+	auction, err := handler.auctionService.CancelAuction(ctx, cancelRequest)
+	if err != nil {
+		errorMsg := NewErrorMessage(err.Error(), msg.AuctionID)
+		return client.Reply(msg, errorMsg)
+	}
+
+	// Subscribers are notified by the auction_cancelled broadcast; the canceller may not be subscribed
+	response := handler.createAuctionResponse(auction, MessageTypeAuctionUpdate, msg.AuctionID)
+
+	handler.logger.Info().Str("auction_id", auction.ID.String()).Str("user_id", client.userID.String()).Msg("Auction cancelled")
+	return client.Reply(msg, response)
+}
+
+// handleUpdateAuction handles editing an auction before its first bid
+func (handler *WsHandler) handleUpdateAuction(client *WsClient, msg *ClientMessage) error {
+	if msg.AuctionID == nil {
+		return shared.ErrAuctionIDRequired
+	}
+
+	ctx := context.Background()
+
+	updateRequest := inbound.UpdateAuctionRequest{
+		AuctionID: *msg.AuctionID,
+		UserID:    client.userID,
+	}
+	if startingPrice, ok := moneyField(msg.Data, "starting_price"); ok {
+		updateRequest.StartingPrice = &startingPrice
+	}
+	if endTime, ok := msg.Data["end_time"].(string); ok {
+		updateRequest.EndTime = &endTime
+	}
+
+	auction, err := handler.auctionService.UpdateAuction(ctx, updateRequest)
+	if err != nil {
+		errorMsg := NewErrorMessage(err.Error(), msg.AuctionID)
+		return client.Reply(msg, errorMsg)
+	}
+
+	// Subscribers are notified by the auction_updated broadcast; the editor may not be subscribed
+	response := handler.createAuctionResponse(auction, MessageTypeAuctionUpdate, msg.AuctionID)
+
+	handler.logger.Info().Str("auction_id", auction.ID.String()).Str("user_id", client.userID.String()).Msg("Auction updated")
+	return client.Reply(msg, response)
 }
 
 // handleGetAuction handles getting auction details
@@ -399,12 +1364,12 @@ func (handler *WsHandler) handleGetAuction(client *WsClient, msg *ClientMessage)
 	auction, err := handler.auctionService.GetAuction(ctx, *msg.AuctionID)
 	if err != nil {
 		errorMsg := NewErrorMessage(err.Error(), msg.AuctionID)
-		return client.Send(errorMsg)
+		return client.Reply(msg, errorMsg)
 	}
 
 	response := handler.createAuctionResponse(auction, MessageTypeAuctionUpdate, msg.AuctionID)
 
-	return client.Send(response)
+	return client.Reply(msg, response)
 }
 
 // handleListAuctions handles listing auctions
@@ -431,31 +1396,73 @@ func (handler *WsHandler) handleListAuctions(client *WsClient, msg *ClientMessag
 	auctions, err := handler.auctionService.ListAuctions(ctx, auctionRequest)
 	if err != nil {
 		errorMsg := NewErrorMessage(err.Error(), nil)
-		return client.Send(errorMsg)
+		return client.Reply(msg, errorMsg)
 	}
 
 	// Send auctions data
 	response := NewServerMessage(MessageTypeAuctionUpdate)
-	response.Data["auctions"] = auctions
+	response.Data["auctions"] = auction.NewViews(auctions, handler.buyNowThreshold)
 	response.Data["count"] = len(auctions)
 
-	return client.Send(response)
+	return client.Reply(msg, response)
 }
 
-func (handler *WsHandler) createAuctionResponse(auction *auction.Auction, msgType MessageType, auctionID *uuid.UUID) *ServerMessage {
+func (handler *WsHandler) createAuctionResponse(a *auction.Auction, msgType MessageType, auctionID *uuid.UUID) *ServerMessage {
 	response := NewServerMessage(msgType)
 	if auctionID != nil {
 		response.AuctionID = auctionID
 	}
 
-	response.Data["auction_id"] = auction.ID
-	response.Data["item_id"] = auction.ItemID
-	response.Data["creator_id"] = auction.CreatorID
-	response.Data["start_time"] = auction.StartTime.Format(time.RFC3339)
-	response.Data["end_time"] = auction.EndTime.Format(time.RFC3339)
-	response.Data["starting_price"] = auction.StartingPrice
-	response.Data["current_price"] = auction.CurrentPrice
-	response.Data["status"] = auction.Status
+	response.Data["auction_id"] = a.ID
+	response.Data["item_id"] = a.ItemID
+	response.Data["creator_id"] = a.CreatorID
+	response.Data["start_time"] = a.StartTime.Format(time.RFC3339)
+	response.Data["end_time"] = a.EndTime.Format(time.RFC3339)
+	response.Data["starting_price"] = a.StartingPrice
+	if !a.PriceHidden() {
+		response.Data["current_price"] = a.CurrentPrice
+	}
+	response.Data["status"] = a.Status
+	response.Data["type"] = a.Type
+	response.Data["currency"] = a.Currency
+	response.Data["quantity"] = a.Quantity
+	if a.IsMultiUnit() {
+		response.Data["pricing"] = a.Pricing
+	}
+	response.Data["soft_close_seconds"] = a.SoftCloseSeconds
+	// Only whether the reserve is met is exposed, never the reserve price itself
+	if !a.PriceHidden() {
+		response.Data["reserve_met"] = a.ReserveMet()
+	}
+	view := auction.NewView(a, handler.buyNowThreshold)
+	response.Data["buy_now_available"] = view.BuyNowAvailable
+	if view.BuyNowPrice != nil {
+		response.Data["buy_now_price"] = *view.BuyNowPrice
+	}
+	if a.IncrementRule != nil {
+		response.Data["increment_rule"] = a.IncrementRule
+	}
+	if a.RelistPolicy != nil {
+		response.Data["relist_policy"] = a.RelistPolicy
+		response.Data["relist_count"] = a.RelistCount
+	}
+	if a.RelistedFrom != nil {
+		response.Data["relisted_from"] = a.RelistedFrom.String()
+	}
+	if view.MinNextBid != nil {
+		response.Data["min_next_bid"] = *view.MinNextBid
+	}
+	if view.MaxNextBid != nil {
+		response.Data["max_next_bid"] = *view.MaxNextBid
+	}
+	if a.IsDutch() {
+		response.Data["price_drop_amount"] = a.PriceDropAmount
+		response.Data["price_drop_interval_seconds"] = a.PriceDropIntervalSeconds
+		response.Data["floor_price"] = a.FloorPrice
+		if nextDrop, ok := a.NextPriceDropAt(time.Now()); ok && a.IsActive() {
+			response.Data["next_price_drop_at"] = nextDrop.Format(time.RFC3339)
+		}
+	}
 
 	return response
 }
@@ -14,25 +14,62 @@ type MessageType string
 
 const (
 	// Client to Server message types
-	MessageTypeSubscribe     MessageType = "subscribe"
-	MessageTypeUnsubscribe   MessageType = "unsubscribe"
-	MessageTypePlaceBid      MessageType = "place_bid"
-	MessageTypeCreateAuction MessageType = "create_auction"
-	MessageTypeGetAuction    MessageType = "get_auction"
-	MessageTypeListAuctions  MessageType = "list_auctions"
-	MessageTypePing          MessageType = "ping"
+	MessageTypeSubscribe           MessageType = "subscribe"
+	MessageTypeUnsubscribe         MessageType = "unsubscribe"
+	MessageTypePlaceBid            MessageType = "place_bid"
+	MessageTypePlaceMaxBid         MessageType = "place_max_bid"
+	MessageTypeRetractBid          MessageType = "retract_bid"
+	MessageTypeBuyNow              MessageType = "buy_now"
+	MessageTypeAcceptPrice         MessageType = "accept_price"
+	MessageTypeCreateAuction       MessageType = "create_auction"
+	MessageTypeCancelAuction       MessageType = "cancel_auction"
+	MessageTypeUpdateAuction       MessageType = "update_auction"
+	MessageTypeOfferSecondChance   MessageType = "offer_second_chance"
+	MessageTypeAcceptSecondChance  MessageType = "accept_second_chance"
+	MessageTypeDeclineSecondChance MessageType = "decline_second_chance"
+	MessageTypePayOrder            MessageType = "pay_order"
+	MessageTypeGetBalance          MessageType = "get_balance"
+	MessageTypeGetStatement        MessageType = "get_statement"
+	MessageTypeSetCreditLimit      MessageType = "set_credit_limit"
+	MessageTypeGetAuction          MessageType = "get_auction"
+	MessageTypeListAuctions        MessageType = "list_auctions"
+	MessageTypeSetCurrency         MessageType = "set_currency"
+	MessageTypePing                MessageType = "ping"
 
 	// Server to Client message types
-	MessageTypeBidPlaced      MessageType = "bid_placed"
-	MessageTypeAuctionEnded   MessageType = "auction_ended"
-	MessageTypeAuctionUpdate  MessageType = "auction_update"
-	MessageTypeAuctionCreated MessageType = "auction_created"
-	MessageTypeError          MessageType = "error"
-	MessageTypePong           MessageType = "pong"
+	MessageTypeBidPlaced            MessageType = "bid_placed"
+	MessageTypeBidAccepted          MessageType = "bid_accepted"
+	MessageTypeBidRetracted         MessageType = "bid_retracted"
+	MessageTypeAuctionStarted       MessageType = "auction_started"
+	MessageTypeAuctionEnded         MessageType = "auction_ended"
+	MessageTypeAuctionCancelled     MessageType = "auction_cancelled"
+	MessageTypeAuctionExtended      MessageType = "auction_extended"
+	MessageTypeAuctionUpdate        MessageType = "auction_update"
+	MessageTypeAuctionSnapshot      MessageType = "auction_snapshot"
+	MessageTypeAuctionUpdated       MessageType = "auction_updated"
+	MessageTypeAuctionRelisted      MessageType = "auction_relisted"
+	MessageTypeAuctionCreated       MessageType = "auction_created"
+	MessageTypeMaxBidPlaced         MessageType = "max_bid_placed"
+	MessageTypeBidSubmitted         MessageType = "bid_submitted"
+	MessageTypePriceDropped         MessageType = "price_dropped"
+	MessageTypeSecondChanceOffer    MessageType = "second_chance_offer"
+	MessageTypeSecondChanceAnswered MessageType = "second_chance_answered"
+	MessageTypeOrderCreated         MessageType = "order_created"
+	MessageTypeOrderPaid            MessageType = "order_paid"
+	MessageTypeOrderPaymentFailed   MessageType = "order_payment_failed"
+	MessageTypeOrderExpired         MessageType = "order_expired"
+	MessageTypeBalance              MessageType = "balance"
+	MessageTypeStatement            MessageType = "statement"
+	MessageTypeCreditLimitUpdated   MessageType = "credit_limit_updated"
+	MessageTypeCurrencyUpdated      MessageType = "currency_updated"
+	MessageTypeError                MessageType = "error"
+	MessageTypePong                 MessageType = "pong"
 )
 
 type ClientMessage struct {
-	Type      MessageType            `json:"type"`
+	Type MessageType `json:"type"`
+	// RequestID is chosen by the client and echoed in the direct reply to the message
+	RequestID string                 `json:"request_id,omitempty"`
 	AuctionID *uuid.UUID             `json:"auction_id,omitempty"`
 	Data      map[string]interface{} `json:"data,omitempty"`
 	Timestamp int64                  `json:"timestamp"`
@@ -40,7 +77,11 @@ type ClientMessage struct {
 
 // ServerMessage represents a message sent from server to client
 type ServerMessage struct {
-	Type      MessageType            `json:"type"`
+	Type MessageType `json:"type"`
+	// RequestID is set on replies to a client message that carried one, never on broadcasts
+	RequestID string `json:"request_id,omitempty"`
+	// Seq is the auction's sequence number of the broadcast event the message carries
+	Seq       int64                  `json:"seq,omitempty"`
 	AuctionID *uuid.UUID             `json:"auction_id,omitempty"`
 	Data      map[string]interface{} `json:"data,omitempty"`
 	Error     *string                `json:"error,omitempty"`
@@ -49,17 +90,17 @@ type ServerMessage struct {
 
 // BidData represents bid information in messages
 type BidData struct {
-	BidID     uuid.UUID `json:"bid_id"`
-	UserID    uuid.UUID `json:"user_id"`
-	Amount    float64   `json:"amount"`
-	Timestamp time.Time `json:"timestamp"`
+	BidID     uuid.UUID    `json:"bid_id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	Amount    shared.Money `json:"amount"`
+	Timestamp time.Time    `json:"timestamp"`
 }
 
 type AuctionData struct {
-	AuctionID    uuid.UUID `json:"auction_id"`
-	CurrentPrice float64   `json:"current_price"`
-	Status       string    `json:"status"`
-	EndTime      time.Time `json:"end_time"`
+	AuctionID    uuid.UUID    `json:"auction_id"`
+	CurrentPrice shared.Money `json:"current_price"`
+	Status       string       `json:"status"`
+	EndTime      time.Time    `json:"end_time"`
 }
 
 func NewServerMessage(msgType MessageType) *ServerMessage {
@@ -80,7 +121,7 @@ func NewErrorMessage(err string, auctionID *uuid.UUID) *ServerMessage {
 }
 
 // NewAuctionEndedMessage creates an auction ended message
-func NewAuctionEndedMessage(auctionID uuid.UUID, winnerID *uuid.UUID, finalPrice float64) *ServerMessage {
+func NewAuctionEndedMessage(auctionID uuid.UUID, winnerID *uuid.UUID, finalPrice shared.Money) *ServerMessage {
 	msg := NewServerMessage(MessageTypeAuctionEnded)
 	msg.AuctionID = &auctionID
 	msg.Data["final_price"] = finalPrice
@@ -89,6 +130,49 @@ func NewAuctionEndedMessage(auctionID uuid.UUID, winnerID *uuid.UUID, finalPrice
 	}
 	return msg
 }
+
+// moneyField reads an amount sent either as a decimal string ("150.00") or as a JSON number
+func moneyField(data map[string]interface{}, key string) (shared.Money, bool) {
+	var amount shared.Money
+	var err error
+	switch value := data[key].(type) {
+	case string:
+		amount, err = shared.ParseMoney(value, shared.DefaultCurrency)
+	case float64:
+		amount, err = shared.MoneyFromFloat(value, shared.DefaultCurrency)
+	default:
+		return shared.Money{}, false
+	}
+	return amount, err == nil
+}
+
+// currencyField reads an optional currency code; a missing field yields an empty currency
+func currencyField(data map[string]interface{}, key string) (shared.Currency, error) {
+	value, ok := data[key]
+	if !ok || value == nil {
+		return "", nil
+	}
+	code, ok := value.(string)
+	if !ok {
+		return "", shared.ErrInvalidCurrency
+	}
+	return shared.ParseCurrency(code)
+}
+
+// sinceSeqField reads the optional sequence number of the last event a client received;
+// ok is false when the field is missing
+func sinceSeqField(data map[string]interface{}) (seq int64, ok bool, err error) {
+	value, exists := data["since_seq"]
+	if !exists || value == nil {
+		return 0, false, nil
+	}
+	number, isNumber := value.(float64)
+	if !isNumber || number < 0 || number != float64(int64(number)) {
+		return 0, false, shared.ErrInvalidSinceSeq
+	}
+	return int64(number), true, nil
+}
+
 func (m *ClientMessage) validateAuctionID() error {
 	if m.AuctionID == nil || *m.AuctionID == uuid.Nil {
 		return shared.ErrAuctionIDRequired
@@ -114,7 +198,14 @@ func ParseClientMessage(data []byte) (*ClientMessage, error) {
 // Validate validates a client message
 func (m *ClientMessage) Validate() error {
 	switch m.Type {
-	case MessageTypeSubscribe, MessageTypeUnsubscribe:
+	case MessageTypeSubscribe:
+		if err := m.validateAuctionID(); err != nil {
+			return err
+		}
+		if _, _, err := sinceSeqField(m.Data); err != nil {
+			return err
+		}
+	case MessageTypeUnsubscribe:
 		if err := m.validateAuctionID(); err != nil {
 			return err
 		}
@@ -122,10 +213,31 @@ func (m *ClientMessage) Validate() error {
 		if err := m.validateAuctionID(); err != nil {
 			return err
 		}
-		amount, ok := m.Data["amount"].(float64)
-		if !ok || amount <= 0 {
+		amount, ok := moneyField(m.Data, "amount")
+		if !ok || !amount.IsPositive() {
 			return shared.ErrInvalidAmount
 		}
+		if _, err := currencyField(m.Data, "currency"); err != nil {
+			return err
+		}
+	case MessageTypePlaceMaxBid:
+		if err := m.validateAuctionID(); err != nil {
+			return err
+		}
+		maxAmount, ok := moneyField(m.Data, "max_amount")
+		if !ok || !maxAmount.IsPositive() {
+			return shared.ErrInvalidMaxAmount
+		}
+		if _, err := currencyField(m.Data, "currency"); err != nil {
+			return err
+		}
+	case MessageTypeRetractBid:
+		if err := m.validateAuctionID(); err != nil {
+			return err
+		}
+		if bidID, ok := m.Data["bid_id"].(string); !ok || bidID == "" {
+			return shared.ErrBidIDRequired
+		}
 	case MessageTypeCreateAuction:
 		if m.Data["item_id"] == nil {
 			return shared.ErrItemIDRequired
@@ -139,11 +251,31 @@ func (m *ClientMessage) Validate() error {
 		if m.Data["starting_price"] == nil {
 			return shared.ErrStartingPriceRequired
 		}
-	case MessageTypeGetAuction:
+		if _, err := currencyField(m.Data, "currency"); err != nil {
+			return err
+		}
+	case MessageTypeSetCurrency:
+		if currency, err := currencyField(m.Data, "currency"); err != nil || currency == "" {
+			return shared.ErrInvalidCurrency
+		}
+	case MessageTypeAcceptSecondChance, MessageTypeDeclineSecondChance:
+		if offerID, ok := m.Data["offer_id"].(string); !ok || offerID == "" {
+			return shared.ErrOfferIDRequired
+		}
+	case MessageTypeSetCreditLimit:
+		if userID, ok := m.Data["user_id"].(string); !ok || userID == "" {
+			return shared.ErrUserIDRequired
+		}
+	case MessageTypePayOrder:
+		if orderID, ok := m.Data["order_id"].(string); !ok || orderID == "" {
+			return shared.ErrOrderIDRequired
+		}
+	case MessageTypeGetAuction, MessageTypeBuyNow, MessageTypeAcceptPrice, MessageTypeCancelAuction, MessageTypeUpdateAuction,
+		MessageTypeOfferSecondChance:
 		if err := m.validateAuctionID(); err != nil {
 			return err
 		}
-	case MessageTypeListAuctions:
+	case MessageTypeListAuctions, MessageTypeGetBalance, MessageTypeGetStatement:
 
 	case MessageTypePing:
 
@@ -20,25 +20,45 @@ type Server struct {
 	logger     zerolog.Logger
 }
 
+// RouteRegistrar adds routes served next to the WebSocket endpoint, such as the HTTP API
+type RouteRegistrar interface {
+	RegisterRoutes(mux *http.ServeMux)
+}
+
 type ServerParams struct {
-	Config         *config.Config
-	AuctionService inbound.AuctionService
-	BidService     inbound.BidService
-	Broadcaster    outbound.Broadcaster
-	Logger         zerolog.Logger
+	Config            *config.Config
+	AuctionService    inbound.AuctionService
+	BidService        inbound.BidService
+	SettlementService inbound.SettlementService
+	LedgerService     inbound.LedgerService
+	UserService       inbound.UserService
+	Broadcaster       outbound.Broadcaster
+	FXRates           outbound.FXRateProvider
+	Routes            []RouteRegistrar
+	Logger            zerolog.Logger
 }
 
 func NewServer(params ServerParams) *Server {
 	handler := NewHandler(WsHandlerParams{
-		AuctionService: params.AuctionService,
-		BidService:     params.BidService,
-		Broadcaster:    params.Broadcaster,
-		Logger:         params.Logger,
+		AuctionService:    params.AuctionService,
+		BidService:        params.BidService,
+		SettlementService: params.SettlementService,
+		LedgerService:     params.LedgerService,
+		UserService:       params.UserService,
+		Broadcaster:       params.Broadcaster,
+		FXRates:           params.FXRates,
+		BuyNowThreshold:   params.Config.Auction.BuyNowThreshold,
+		SSEKeepAlive:      params.Config.Server.SSEKeepAliveInterval,
+		Logger:            params.Logger,
 	})
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("/ws", handler.HandleWebSocket)
 	mux.HandleFunc("/health", handleHealth)
+	mux.HandleFunc("GET /auctions/{auction_id}/events", handler.HandleEvents)
+	for _, routes := range params.Routes {
+		routes.RegisterRoutes(mux)
+	}
 
 	httpServer := &http.Server{
 		Addr:         fmt.Sprintf(":%s", params.Config.Server.Port),
@@ -47,6 +67,8 @@ func NewServer(params ServerParams) *Server {
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Minute,
 	}
+	// Event streams never go idle, so they are ended when shutdown begins
+	httpServer.RegisterOnShutdown(handler.CloseStreams)
 
 	return &Server{
 		handler:    handler,
@@ -0,0 +1,183 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"troffee-auction-service/internal/domain/shared"
+	"troffee-auction-service/internal/ports/outbound"
+
+	"github.com/google/uuid"
+)
+
+// HandleEvents streams the events of one auction as Server-Sent Events to read-only watchers.
+// Each event carries the same message a WebSocket subscriber would receive, with the auction's
+// sequence number as event ID so that a reconnecting EventSource resumes from its Last-Event-ID header.
+func (handler *WsHandler) HandleEvents(w http.ResponseWriter, r *http.Request) {
+	auctionID, err := uuid.Parse(r.PathValue("auction_id"))
+	if err != nil {
+		http.Error(w, "invalid auction ID", http.StatusBadRequest)
+		return
+	}
+
+	currency, err := shared.ParseCurrency(r.URL.Query().Get("currency"))
+	if err != nil && r.URL.Query().Has("currency") {
+		http.Error(w, "invalid currency", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := handler.auctionService.GetAuction(r.Context(), auctionID); err != nil {
+		if errors.Is(err, shared.ErrAuctionNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		handler.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to load auction for event stream")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	// Streams outlive the server's write timeout, so it is lifted for this response
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		handler.logger.Error().Err(err).Msg("Event streaming is not supported by the connection")
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	// Watch before replaying so nothing published in between is lost. Streams of the same
+	// auction share its subscription; the channel is never closed by the broadcaster.
+	eventChan := make(chan outbound.Event, 100)
+	if err := handler.broadcaster.Watch(r.Context(), auctionID, eventChan); err != nil {
+		handler.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to subscribe event stream")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	defer handler.broadcaster.Unwatch(context.Background(), auctionID, eventChan)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// Events replayed from history may arrive again from the subscription and are skipped
+	var lastSeq int64
+	replayed := 0
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		sinceSeq, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || sinceSeq < 0 {
+			sinceSeq = 0
+		}
+
+		missed, err := handler.broadcaster.EventsSince(r.Context(), auctionID, sinceSeq)
+		if err != nil {
+			if !errors.Is(err, shared.ErrEventsExpired) {
+				handler.logger.Warn().Err(err).Str("auction_id", auctionID.String()).Int64("since_seq", sinceSeq).Msg("Failed to replay missed events")
+			}
+			// The current state of the auction stands in for events no longer retained
+			if err := handler.writeSnapshot(r.Context(), w, auctionID); err != nil {
+				return
+			}
+		}
+		for _, event := range missed {
+			if err := handler.writeEvent(w, event, currency); err != nil {
+				return
+			}
+			lastSeq = event.Seq
+		}
+		replayed = len(missed)
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	handler.logger.Info().Str("auction_id", auctionID.String()).Int("replayed", replayed).Msg("Event stream opened")
+
+	keepAlive := time.NewTicker(handler.sseKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case event := <-eventChan:
+			if event.Seq > 0 {
+				if event.Seq <= lastSeq {
+					continue
+				}
+				lastSeq = event.Seq
+			}
+
+			if err := handler.writeEvent(w, event, currency); err != nil {
+				return
+			}
+
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+
+		case <-handler.streamsDone:
+			return
+
+		case <-r.Context().Done():
+			handler.logger.Info().Str("auction_id", auctionID.String()).Msg("Event stream closed")
+			return
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// writeEvent writes one event in the text/event-stream format, named after its message type
+func (handler *WsHandler) writeEvent(w http.ResponseWriter, event outbound.Event, currency shared.Currency) error {
+	msg := handler.convertEventToMessage(event)
+	if msg.Type == MessageTypeBidPlaced {
+		msg = handler.withConvertedAmounts(msg, currency)
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		handler.logger.Error().Err(err).Str("event_type", string(event.Type)).Msg("Failed to marshal event for stream")
+		return nil
+	}
+
+	if event.Seq > 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", event.Seq); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", msg.Type, data)
+	return err
+}
+
+// writeSnapshot writes the current state of an auction as an auction_snapshot event. It has no
+// ID, so a later reconnect still resumes from the last numbered event.
+func (handler *WsHandler) writeSnapshot(ctx context.Context, w http.ResponseWriter, auctionID uuid.UUID) error {
+	auction, err := handler.auctionService.GetAuction(ctx, auctionID)
+	if err != nil {
+		handler.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to load auction snapshot for stream")
+		return nil
+	}
+
+	data, err := json.Marshal(handler.createAuctionResponse(auction, MessageTypeAuctionSnapshot, &auctionID))
+	if err != nil {
+		handler.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to marshal auction snapshot for stream")
+		return nil
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", MessageTypeAuctionSnapshot, data)
+	return err
+}
+
+// CloseStreams ends every open event stream; HTTP server shutdown does not wait for them
+func (handler *WsHandler) CloseStreams() {
+	handler.streamsOnce.Do(func() {
+		close(handler.streamsDone)
+	})
+}
@@ -2,10 +2,12 @@ package app
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"troffee-auction-service/internal/adapters/scheduler"
 	"troffee-auction-service/internal/domain/auction"
+	"troffee-auction-service/internal/domain/bid"
 	"troffee-auction-service/internal/domain/shared"
 	"troffee-auction-service/internal/ports/inbound"
 	"troffee-auction-service/internal/ports/outbound"
@@ -20,7 +22,11 @@ type AuctionService struct {
 	itemRepo    outbound.ItemRepository
 	userRepo    outbound.UserRepository
 	bidRepo     outbound.BidRepository
+	offerRepo   outbound.SecondChanceOfferRepository
+	broadcaster outbound.Broadcaster
 	scheduler   *scheduler.AuctionScheduler
+	settlement  *SettlementService
+	offerTTL    time.Duration
 	logger      zerolog.Logger
 }
 type AuctionServiceParams struct {
@@ -28,8 +34,14 @@ type AuctionServiceParams struct {
 	ItemRepo    outbound.ItemRepository
 	UserRepo    outbound.UserRepository
 	BidRepo     outbound.BidRepository
+	OfferRepo   outbound.SecondChanceOfferRepository
+	Broadcaster outbound.Broadcaster
 	Scheduler   *scheduler.AuctionScheduler
-	Logger      zerolog.Logger
+	// Settlement opens orders for winners; auctions end without orders when nil
+	Settlement *SettlementService
+	// OfferTTL is how long a runner-up has to answer a second-chance offer
+	OfferTTL time.Duration
+	Logger   zerolog.Logger
 }
 
 // NewAuctionService creates a new auction service
@@ -39,7 +51,11 @@ func NewAuctionService(params AuctionServiceParams) *AuctionService {
 		itemRepo:    params.ItemRepo,
 		userRepo:    params.UserRepo,
 		bidRepo:     params.BidRepo,
+		offerRepo:   params.OfferRepo,
+		broadcaster: params.Broadcaster,
 		scheduler:   params.Scheduler,
+		settlement:  params.Settlement,
+		offerTTL:    params.OfferTTL,
 		logger:      params.Logger.With().Str("component", "auction_service").Logger(),
 	}
 }
@@ -51,7 +67,7 @@ func (service *AuctionService) CreateAuction(ctx context.Context, req inbound.Cr
 		Str("creator_id", req.CreatorID.String()).
 		Str("start_time", req.StartTime).
 		Str("end_time", req.EndTime).
-		Float64("starting_price", req.StartingPrice).
+		Stringer("starting_price", req.StartingPrice).
 		Msg("Attempting to create auction")
 
 	// Validate item exists
@@ -102,17 +118,8 @@ func (service *AuctionService) CreateAuction(ctx context.Context, req inbound.Cr
 		return nil, shared.ErrInvalidStartTime
 	}
 
-	if endTime.Before(startTime) {
-		service.logger.Warn().
-			Time("start_time", startTime).
-			Time("end_time", endTime).
-			Msg("End time cannot be before start time")
-		return nil, shared.ErrInvalidEndTime
-	}
-
-	if req.StartingPrice <= 0 {
-		service.logger.Warn().Float64("starting_price", req.StartingPrice).Msg("Starting price must be greater than 0")
-		return nil, shared.ErrInvalidStartingPrice
+	if err := service.validateAuctionTerms(&req, startTime, endTime); err != nil {
+		return nil, err
 	}
 
 	// Check if item is already in an active auction
@@ -132,25 +139,46 @@ func (service *AuctionService) CreateAuction(ctx context.Context, req inbound.Cr
 
 	// Create auction
 	auction := &auction.Auction{
-		ID:            uuid.New(),
-		ItemID:        item.ID,
-		CreatorID:     user.ID,
-		StartTime:     startTime,
-		EndTime:       endTime,
-		StartingPrice: req.StartingPrice,
-		CurrentPrice:  req.StartingPrice,
-		Status:        auction.StatusActive,
-		CreatedAt:     now,
-		UpdatedAt:     now,
+		ID:               uuid.New(),
+		ItemID:           item.ID,
+		CreatorID:        user.ID,
+		StartTime:        startTime,
+		EndTime:          endTime,
+		StartingPrice:    req.StartingPrice,
+		CurrentPrice:     req.StartingPrice,
+		Status:           auction.StatusPending,
+		Type:             req.Type,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+		SoftCloseSeconds: req.SoftCloseSeconds,
+		ReservePrice:     req.ReservePrice,
+		BuyNowPrice:      req.BuyNowPrice,
+		IncrementRule:    req.IncrementRule,
+		Quantity:         req.Quantity,
+		Pricing:          req.Pricing,
+		RelistPolicy:     req.RelistPolicy,
 	}
 
+	// Auctions open for bidding at StartTime; one starting right away skips the pending state
+	if !startTime.After(now) {
+		auction.Start()
+	}
+
+	if auction.IsDutch() {
+		auction.PriceDropAmount = req.PriceDropAmount
+		auction.PriceDropIntervalSeconds = req.PriceDropIntervalSeconds
+		auction.FloorPrice = req.FloorPrice
+	}
+	auction.SetCurrency(req.Currency)
+
 	service.logger.Info().
 		Str("auction_id", auction.ID.String()).
 		Str("item_id", auction.ItemID.String()).
 		Str("creator_id", auction.CreatorID.String()).
 		Time("start_time", auction.StartTime).
 		Time("end_time", auction.EndTime).
-		Float64("starting_price", auction.StartingPrice).
+		Stringer("starting_price", auction.StartingPrice).
+		Str("currency", string(auction.Currency)).
 		Msg("Created auction object")
 
 	// Save to database
@@ -174,11 +202,142 @@ func (service *AuctionService) CreateAuction(ctx context.Context, req inbound.Cr
 				Time("end_time", auction.EndTime).
 				Msg("Auction scheduled for expiration")
 		}
+
+		if auction.IsPending() {
+			if err := service.scheduler.ScheduleStart(auction.ID, auction.StartTime); err != nil {
+				service.logger.Error().Err(err).Str("auction_id", auction.ID.String()).Msg("Failed to schedule auction start")
+			}
+		}
+
+		if nextDrop, ok := auction.NextPriceDropAt(now); ok {
+			if err := service.scheduler.SchedulePriceDrop(auction.ID, nextDrop); err != nil {
+				service.logger.Error().Err(err).Str("auction_id", auction.ID.String()).Msg("Failed to schedule price drop")
+			}
+		}
 	}
 
 	return auction, nil
 }
 
+// validateAuctionTerms checks the timing, pricing and format rules shared by
+// CreateAuction and UpdateAuction, filling in defaults for optional fields
+func (service *AuctionService) validateAuctionTerms(req *inbound.CreateAuctionRequest, startTime, endTime time.Time) error {
+	if endTime.Before(startTime) {
+		service.logger.Warn().
+			Time("start_time", startTime).
+			Time("end_time", endTime).
+			Msg("End time cannot be before start time")
+		return shared.ErrInvalidEndTime
+	}
+
+	if !req.StartingPrice.IsPositive() {
+		service.logger.Warn().Stringer("starting_price", req.StartingPrice).Msg("Starting price must be greater than 0")
+		return shared.ErrInvalidStartingPrice
+	}
+
+	if req.ReservePrice != nil && !req.ReservePrice.GreaterThan(req.StartingPrice) {
+		service.logger.Warn().Stringer("starting_price", req.StartingPrice).Msg("Reserve price must be higher than starting price")
+		return shared.ErrInvalidReservePrice
+	}
+
+	if req.BuyNowPrice != nil && (!req.BuyNowPrice.GreaterThan(req.StartingPrice) ||
+		(req.ReservePrice != nil && req.BuyNowPrice.LessThan(*req.ReservePrice))) {
+		service.logger.Warn().Stringer("buy_now_price", *req.BuyNowPrice).Msg("Buy now price must be higher than starting and reserve price")
+		return shared.ErrInvalidBuyNowPrice
+	}
+
+	if req.Currency == "" {
+		req.Currency = shared.DefaultCurrency
+	}
+	if !req.Currency.Valid() {
+		service.logger.Warn().Str("currency", string(req.Currency)).Msg("Invalid currency")
+		return shared.ErrInvalidCurrency
+	}
+
+	if req.SoftCloseSeconds < 0 {
+		service.logger.Warn().Int("soft_close_seconds", req.SoftCloseSeconds).Msg("Soft close window cannot be negative")
+		return shared.ErrInvalidSoftCloseWindow
+	}
+
+	if req.IncrementRule != nil && !req.IncrementRule.Valid() {
+		service.logger.Warn().Interface("increment_rule", req.IncrementRule).Msg("Invalid increment rule")
+		return shared.ErrInvalidIncrementRule
+	}
+
+	if req.RelistPolicy != nil && !req.RelistPolicy.Valid() {
+		service.logger.Warn().Interface("relist_policy", req.RelistPolicy).Msg("Invalid relist policy")
+		return shared.ErrInvalidRelistPolicy
+	}
+
+	if req.Quantity == 0 {
+		req.Quantity = 1
+	}
+	if req.Quantity < 0 {
+		service.logger.Warn().Int("quantity", req.Quantity).Msg("Quantity must be at least 1")
+		return shared.ErrInvalidQuantity
+	}
+
+	if req.Pricing == "" {
+		req.Pricing = auction.PricingPayAsBid
+	}
+	if req.Pricing != auction.PricingPayAsBid && req.Pricing != auction.PricingUniform {
+		service.logger.Warn().Str("pricing", string(req.Pricing)).Msg("Unknown pricing")
+		return shared.ErrInvalidPricing
+	}
+
+	if req.Type == "" {
+		req.Type = auction.TypeEnglish
+	}
+
+	// Units are allocated to the highest bidders, which only fits ascending open bidding
+	if req.Quantity > 1 && (req.Type != auction.TypeEnglish || req.BuyNowPrice != nil) {
+		service.logger.Warn().Str("type", string(req.Type)).Int("quantity", req.Quantity).Msg("Multi-unit auctions must be English auctions without buy now")
+		return shared.ErrUnsupportedAuctionType
+	}
+
+	switch req.Type {
+	case auction.TypeEnglish:
+	case auction.TypeDutch:
+		if req.ReservePrice != nil || req.BuyNowPrice != nil || req.SoftCloseSeconds > 0 || req.IncrementRule != nil {
+			service.logger.Warn().Str("type", string(req.Type)).Msg("Reserve, buy now, soft close and increments are not supported for Dutch auctions")
+			return shared.ErrUnsupportedAuctionType
+		}
+
+		if !req.PriceDropAmount.IsPositive() || req.PriceDropIntervalSeconds <= 0 ||
+			req.FloorPrice.IsNegative() || !req.FloorPrice.LessThan(req.StartingPrice) {
+			service.logger.Warn().
+				Stringer("price_drop_amount", req.PriceDropAmount).
+				Int("price_drop_interval_seconds", req.PriceDropIntervalSeconds).
+				Stringer("floor_price", req.FloorPrice).
+				Msg("Invalid price drop rule")
+			return shared.ErrInvalidPriceDropRule
+		}
+	case auction.TypeReverse:
+		// The starting price is the buyer's ceiling; reserve and buy-now assume ascending prices
+		if req.ReservePrice != nil || req.BuyNowPrice != nil {
+			service.logger.Warn().Str("type", string(req.Type)).Msg("Reserve and buy now are not supported for reverse auctions")
+			return shared.ErrUnsupportedAuctionType
+		}
+
+		// Lowering the ceiling would only make an unsold reverse auction less attractive
+		if req.RelistPolicy != nil && req.RelistPolicy.PriceDropPercent > 0 {
+			service.logger.Warn().Str("type", string(req.Type)).Msg("Relist price drops are not supported for reverse auctions")
+			return shared.ErrUnsupportedAuctionType
+		}
+	case auction.TypeSealedFirstPrice, auction.TypeSealedSecondPrice:
+		// Both would reveal the bidding before close
+		if req.BuyNowPrice != nil || req.SoftCloseSeconds > 0 || req.IncrementRule != nil {
+			service.logger.Warn().Str("type", string(req.Type)).Msg("Buy now, soft close and increments are not supported for sealed auctions")
+			return shared.ErrUnsupportedAuctionType
+		}
+	default:
+		service.logger.Warn().Str("type", string(req.Type)).Msg("Unknown auction type")
+		return shared.ErrInvalidAuctionType
+	}
+
+	return nil
+}
+
 // GetAuction retrieves an auction by ID
 func (client *AuctionService) GetAuction(ctx context.Context, auctionID uuid.UUID) (*auction.Auction, error) {
 	client.logger.Debug().Str("auction_id", auctionID.String()).Msg("Retrieving auction")
@@ -214,57 +373,250 @@ func (client *AuctionService) ListAuctions(ctx context.Context, req inbound.List
 
 // EndAuction ends an auction (implements inbound.AuctionService interface)
 func (client *AuctionService) EndAuction(ctx context.Context, auctionID uuid.UUID) error {
-	_, err := client.endAuctionWithResult(ctx, auctionID)
-	return err
+	result, err := client.endAuctionWithResult(ctx, auctionID)
+	if err != nil {
+		return err
+	}
+
+	client.openOrders(ctx, result)
+	return nil
 }
 
-// endAuctionWithResult ends an auction and returns the result (for scheduler use)
-func (client *AuctionService) endAuctionWithResult(ctx context.Context, auctionID uuid.UUID) (*shared.AuctionEndResult, error) {
-	client.logger.Info().Str("auction_id", auctionID.String()).Msg("Ending auction")
+// UpdateAuction lets the creator or an admin change the starting price and end time of a
+// pending or active auction while it has no bids. The new terms go through the same
+// validation as CreateAuction.
+func (client *AuctionService) UpdateAuction(ctx context.Context, req inbound.UpdateAuctionRequest) (*auction.Auction, error) {
+	client.logger.Info().
+		Str("auction_id", req.AuctionID.String()).
+		Str("user_id", req.UserID.String()).
+		Msg("Attempting to update auction")
 
-	auction, err := client.auctionRepo.GetByID(ctx, auctionID)
+	auction, err := client.auctionRepo.GetByID(ctx, req.AuctionID)
 	if err != nil {
-		client.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to retrieve auction for ending")
+		client.logger.Error().Err(err).Str("auction_id", req.AuctionID.String()).Msg("Failed to retrieve auction for update")
 		return nil, err
 	}
 
-	if auction.IsEnded() {
-		client.logger.Warn().Str("auction_id", auctionID.String()).Msg("Auction already ended")
-		return nil, shared.ErrAuctionAlreadyEnded
+	user, err := client.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("user_id", req.UserID.String()).Msg("User not found")
+		return nil, shared.ErrUserNotFound
+	}
+
+	if auction.CreatorID != user.ID && !user.IsAdmin() {
+		client.logger.Warn().Str("auction_id", req.AuctionID.String()).Str("user_id", req.UserID.String()).Msg("User may not update this auction")
+		return nil, shared.ErrNotAuctionOwner
 	}
 
-	auction.EndAuction()
-	// Get the highest bid to determine winner
-	highestBid, err := client.bidRepo.GetHighestBid(ctx, auctionID)
+	if !auction.IsPending() && !auction.IsActive() {
+		client.logger.Warn().Str("auction_id", req.AuctionID.String()).Str("status", string(auction.Status)).Msg("Auction cannot be updated")
+		return nil, shared.ErrAuctionNotEditable
+	}
+
+	bids, err := client.bidRepo.GetByAuctionID(ctx, req.AuctionID)
 	if err != nil {
-		client.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to get highest bid")
-		//return nil, err
+		client.logger.Error().Err(err).Str("auction_id", req.AuctionID.String()).Msg("Failed to get bids for update")
+		return nil, err
 	}
 
-	// Update auction with winner information if there was a bid
-	result := &shared.AuctionEndResult{
-		AuctionID: auctionID,
-		Status:    string(auction.Status),
+	if len(bids) > 0 {
+		client.logger.Warn().Str("auction_id", req.AuctionID.String()).Int("bids", len(bids)).Msg("Auction with bids cannot be updated")
+		return nil, shared.ErrAuctionHasBids
 	}
 
-	if highestBid != nil {
-		result.WinnerID = &highestBid.UserID
-		result.FinalPrice = &highestBid.Amount
+	endTime := auction.EndTime
+	if req.EndTime != nil {
+		endTime, err = time.Parse(time.RFC3339, *req.EndTime)
+		if err != nil {
+			client.logger.Error().Err(err).Str("end_time", *req.EndTime).Msg("Invalid end time format")
+			return nil, shared.ErrInvalidTimeFormat
+		}
+	}
 
-		client.logger.Info().
-			Str("auction_id", auctionID.String()).
-			Str("winner_id", highestBid.UserID.String()).
-			Float64("final_price", highestBid.Amount).
-			Msg("Auction ended with winner")
-	} else {
-		client.logger.Info().
-			Str("auction_id", auctionID.String()).
-			Msg("Auction ended with no bids")
+	now := time.Now()
+	if !endTime.After(now) {
+		client.logger.Warn().Time("end_time", endTime).Time("current_time", now).Msg("End time cannot be in the past")
+		return nil, shared.ErrEndTimeInPast
+	}
+
+	startingPrice := auction.StartingPrice
+	if req.StartingPrice != nil {
+		startingPrice = *req.StartingPrice
+	}
+
+	terms := inbound.CreateAuctionRequest{
+		ItemID:                   auction.ItemID,
+		CreatorID:                auction.CreatorID,
+		StartingPrice:            startingPrice,
+		SoftCloseSeconds:         auction.SoftCloseSeconds,
+		ReservePrice:             auction.ReservePrice,
+		BuyNowPrice:              auction.BuyNowPrice,
+		Currency:                 auction.Currency,
+		IncrementRule:            auction.IncrementRule,
+		Quantity:                 auction.Quantity,
+		Pricing:                  auction.Pricing,
+		Type:                     auction.Type,
+		PriceDropAmount:          auction.PriceDropAmount,
+		PriceDropIntervalSeconds: auction.PriceDropIntervalSeconds,
+		FloorPrice:               auction.FloorPrice,
+		RelistPolicy:             auction.RelistPolicy,
+	}
+	if err := client.validateAuctionTerms(&terms, auction.StartTime, endTime); err != nil {
+		return nil, err
+	}
+
+	// Without bids the current price follows the starting price (or the Dutch schedule)
+	previousPrice := auction.CurrentPrice
+	auction.StartingPrice = auction.InCurrency(startingPrice)
+	auction.CurrentPrice = auction.DutchPriceAt(now)
+	auction.EndTime = endTime
+	auction.UpdatedAt = now
+
+	if err := client.auctionRepo.UpdateTermsWithOCC(ctx, auction, previousPrice); err != nil {
+		client.logger.Error().Err(err).Str("auction_id", req.AuctionID.String()).Msg("Failed to update auction")
+		return nil, err
+	}
+
+	if client.scheduler != nil {
+		if err := client.scheduler.ScheduleAuction(auction.ID, auction.EndTime); err != nil {
+			client.logger.Error().Err(err).Str("auction_id", auction.ID.String()).Msg("Failed to reschedule auction expiration")
+		}
+
+		if nextDrop, ok := auction.NextPriceDropAt(now); ok {
+			if err := client.scheduler.SchedulePriceDrop(auction.ID, nextDrop); err != nil {
+				client.logger.Error().Err(err).Str("auction_id", auction.ID.String()).Msg("Failed to reschedule price drop")
+			}
+		}
+	}
+
+	if client.broadcaster != nil {
+		event := outbound.Event{
+			Type:      outbound.EventTypeAuctionUpdated,
+			AuctionID: auction.ID,
+			Data: map[string]interface{}{
+				"auction_id":     auction.ID.String(),
+				"starting_price": auction.StartingPrice,
+				"current_price":  auction.CurrentPrice,
+				"end_time":       auction.EndTime.Format(time.RFC3339),
+				"updated_by":     user.ID.String(),
+			},
+			Timestamp: now.Unix(),
+		}
+
+		if err := client.broadcaster.Publish(ctx, auction.ID, event); err != nil {
+			client.logger.Error().Err(err).Str("auction_id", auction.ID.String()).Msg("Failed to broadcast auction updated event")
+		}
 	}
 
-	// Update auction in database
-	if err := client.auctionRepo.Update(ctx, auction); err != nil {
-		client.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to update auction in database")
+	client.logger.Info().
+		Str("auction_id", auction.ID.String()).
+		Stringer("starting_price", auction.StartingPrice).
+		Time("end_time", auction.EndTime).
+		Msg("Auction updated")
+
+	return auction, nil
+}
+
+// CancelAuction withdraws a pending or active auction. Only its creator or an admin may
+// cancel it, and once bids were placed a reason is required. Outstanding bids are rejected.
+func (client *AuctionService) CancelAuction(ctx context.Context, req inbound.CancelAuctionRequest) (*auction.Auction, error) {
+	client.logger.Info().
+		Str("auction_id", req.AuctionID.String()).
+		Str("user_id", req.UserID.String()).
+		Msg("Attempting to cancel auction")
+
+	auction, err := client.auctionRepo.GetByID(ctx, req.AuctionID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("auction_id", req.AuctionID.String()).Msg("Failed to retrieve auction for cancellation")
+		return nil, err
+	}
+
+	user, err := client.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("user_id", req.UserID.String()).Msg("User not found")
+		return nil, shared.ErrUserNotFound
+	}
+
+	if auction.CreatorID != user.ID && !user.IsAdmin() {
+		client.logger.Warn().Str("auction_id", req.AuctionID.String()).Str("user_id", req.UserID.String()).Msg("User may not cancel this auction")
+		return nil, shared.ErrNotAuctionOwner
+	}
+
+	if !auction.IsPending() && !auction.IsActive() {
+		client.logger.Warn().Str("auction_id", req.AuctionID.String()).Str("status", string(auction.Status)).Msg("Auction cannot be cancelled")
+		return nil, shared.ErrAuctionNotCancellable
+	}
+
+	// The status flips first; bids are then rejected in the same transaction, so none placed in between is missed
+	previousStatus := auction.Status
+	rejected, err := client.auctionRepo.CancelWithOCC(ctx, req.AuctionID, previousStatus, req.Reason != "")
+	if err != nil {
+		if errors.Is(err, shared.ErrCancelReasonRequired) {
+			client.logger.Warn().Str("auction_id", req.AuctionID.String()).Msg("Cancelling an auction with bids requires a reason")
+			return nil, err
+		}
+		client.logger.Error().Err(err).Str("auction_id", req.AuctionID.String()).Msg("Failed to cancel auction")
+		return nil, err
+	}
+	auction.Cancel()
+
+	if client.scheduler != nil {
+		if err := client.scheduler.UnscheduleAuction(req.AuctionID); err != nil {
+			client.logger.Error().Err(err).Str("auction_id", req.AuctionID.String()).Msg("Failed to unschedule cancelled auction")
+		}
+	}
+
+	if client.broadcaster != nil {
+		eventData := map[string]interface{}{
+			"auction_id":   req.AuctionID.String(),
+			"status":       string(auction.Status),
+			"cancelled_by": user.ID.String(),
+		}
+		if req.Reason != "" {
+			eventData["reason"] = req.Reason
+		}
+
+		event := outbound.Event{
+			Type:      outbound.EventTypeAuctionCancelled,
+			AuctionID: req.AuctionID,
+			Data:      eventData,
+			Timestamp: time.Now().Unix(),
+		}
+
+		if err := client.broadcaster.Publish(ctx, req.AuctionID, event); err != nil {
+			client.logger.Error().Err(err).Str("auction_id", req.AuctionID.String()).Msg("Failed to broadcast auction cancelled event")
+		}
+	}
+
+	client.logger.Info().
+		Str("auction_id", req.AuctionID.String()).
+		Str("cancelled_by", user.ID.String()).
+		Int("rejected_bids", rejected).
+		Msg("Auction cancelled")
+
+	return auction, nil
+}
+
+// endAuctionWithResult ends an auction and returns the result (for scheduler use). Only an active
+// auction whose end time has passed is ended; its winners are decided in the same transaction.
+func (client *AuctionService) endAuctionWithResult(ctx context.Context, auctionID uuid.UUID) (*shared.AuctionEndResult, error) {
+	client.logger.Info().Str("auction_id", auctionID.String()).Msg("Ending auction")
+
+	result, err := client.auctionRepo.EndWithOCC(ctx, auctionID, time.Now(), client.resolveAuctionEnd)
+	switch {
+	case err == shared.ErrAuctionNotEnded:
+		// A late bid pushed the end time forward after the auction was picked up
+		client.logger.Info().Str("auction_id", auctionID.String()).Msg("Auction was extended, not ending yet")
+		return nil, err
+	case err == shared.ErrAuctionAlreadyEnded:
+		client.logger.Warn().Str("auction_id", auctionID.String()).Msg("Auction already ended")
+		return nil, err
+	case err == shared.ErrAuctionStatusChanged:
+		// Pending and cancelled auctions never end; a cancellation may race the scheduler
+		client.logger.Warn().Str("auction_id", auctionID.String()).Msg("Auction is not active")
+		return nil, err
+	case err != nil:
+		client.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to end auction")
 		return nil, err
 	}
 
@@ -272,12 +624,282 @@ func (client *AuctionService) endAuctionWithResult(ctx context.Context, auctionI
 	return result, nil
 }
 
+// resolveAuctionEnd decides the winners of an auction being ended from all of its bids
+func (client *AuctionService) resolveAuctionEnd(a *auction.Auction, bids []*bid.Bid) *shared.AuctionEndResult {
+	if a.IsSealed() {
+		return client.endSealedAuction(a, bids)
+	}
+	if a.IsMultiUnit() {
+		return client.endMultiUnitAuction(a, bids)
+	}
+
+	result := &shared.AuctionEndResult{
+		AuctionID: a.ID,
+		Status:    string(a.Status),
+		Reason:    shared.EndReasonExpired,
+	}
+
+	// The best bid wins (the lowest one for reverse auctions)
+	ranked := bid.RankBids(bids, a.LowestBidWins())
+	if len(ranked) == 0 {
+		client.logger.Info().
+			Str("auction_id", a.ID.String()).
+			Msg("Auction ended with no bids")
+		return result
+	}
+
+	bestBid := ranked[0]
+	if !a.ReserveMetBy(bestBid.Amount) {
+		// The item is not sold; the reserve itself is never revealed
+		result.Status = shared.AuctionResultReserveNotMet
+
+		client.logger.Info().
+			Str("auction_id", a.ID.String()).
+			Stringer("highest_bid", bestBid.Amount).
+			Msg("Auction ended with reserve not met")
+		return result
+	}
+
+	result.Winners = []shared.AuctionWinner{{
+		UserID:   bestBid.UserID,
+		BidID:    bestBid.ID,
+		Quantity: bestBid.Quantity,
+		Price:    bestBid.Amount,
+	}}
+
+	client.logger.Info().
+		Str("auction_id", a.ID.String()).
+		Str("winner_id", bestBid.UserID.String()).
+		Stringer("final_price", bestBid.Amount).
+		Msg("Auction ended with winner")
+	return result
+}
+
+// endSealedAuction computes the winner and clearing price of a sealed auction and reveals the ranked bids
+func (client *AuctionService) endSealedAuction(a *auction.Auction, bids []*bid.Bid) *shared.AuctionEndResult {
+	ranked := bid.RankSealedBids(bids)
+	result := &shared.AuctionEndResult{
+		AuctionID: a.ID,
+		Status:    string(a.Status),
+		Reason:    shared.EndReasonExpired,
+		Rankings:  make([]shared.BidRanking, 0, len(ranked)),
+	}
+
+	amounts := make([]shared.Money, 0, len(ranked))
+	for i, rankedBid := range ranked {
+		result.Rankings = append(result.Rankings, shared.BidRanking{
+			Rank:   i + 1,
+			UserID: rankedBid.UserID,
+			Amount: rankedBid.Amount,
+		})
+		amounts = append(amounts, rankedBid.Amount)
+	}
+
+	switch {
+	case len(ranked) == 0:
+		client.logger.Info().Str("auction_id", a.ID.String()).Msg("Sealed auction ended with no bids")
+	case !a.ReserveMetBy(ranked[0].Amount):
+		result.Status = shared.AuctionResultReserveNotMet
+		client.logger.Info().Str("auction_id", a.ID.String()).Msg("Sealed auction ended with reserve not met")
+	default:
+		clearingPrice := a.ClearingPrice(amounts)
+		a.CurrentPrice = clearingPrice
+		result.Winners = []shared.AuctionWinner{{
+			UserID:   ranked[0].UserID,
+			BidID:    ranked[0].ID,
+			Quantity: ranked[0].Quantity,
+			Price:    clearingPrice,
+		}}
+
+		client.logger.Info().
+			Str("auction_id", a.ID.String()).
+			Str("winner_id", ranked[0].UserID.String()).
+			Stringer("final_price", clearingPrice).
+			Msg("Sealed auction ended with winner")
+	}
+
+	return result
+}
+
+// endMultiUnitAuction allocates the units to the highest bids. The reserve applies per unit.
+func (client *AuctionService) endMultiUnitAuction(a *auction.Auction, bids []*bid.Bid) *shared.AuctionEndResult {
+	eligible := make([]*bid.Bid, 0, len(bids))
+	for _, b := range bids {
+		if b.IsAccepted() && a.ReserveMetBy(b.Amount) {
+			eligible = append(eligible, b)
+		}
+	}
+
+	allocations := bid.AllocateUnits(eligible, a.Quantity)
+	result := &shared.AuctionEndResult{
+		AuctionID: a.ID,
+		Status:    string(a.Status),
+		Reason:    shared.EndReasonExpired,
+	}
+
+	for _, allocation := range allocations {
+		result.Winners = append(result.Winners, shared.AuctionWinner{
+			UserID:   allocation.Bid.UserID,
+			BidID:    allocation.Bid.ID,
+			Quantity: allocation.Quantity,
+			Price:    allocation.Bid.Amount,
+		})
+	}
+
+	// Uniform pricing charges everyone the lowest winning bid
+	if a.HasUniformPricing() && len(allocations) > 0 {
+		clearingPrice := allocations[len(allocations)-1].Bid.Amount
+		for i := range result.Winners {
+			result.Winners[i].Price = clearingPrice
+		}
+	}
+
+	if !result.HasWinner() && len(bids) > 0 && a.HasReserve() {
+		result.Status = shared.AuctionResultReserveNotMet
+	}
+
+	client.logger.Info().
+		Str("auction_id", a.ID.String()).
+		Int("winners", len(result.Winners)).
+		Int("units_sold", result.UnitsSold()).
+		Int("quantity", a.Quantity).
+		Msg("Multi-unit auction ended")
+
+	return result
+}
+
 // SetScheduler sets the auction scheduler
 func (client *AuctionService) SetScheduler(scheduler *scheduler.AuctionScheduler) {
 	client.scheduler = scheduler
 }
 
+// StartAuctionForScheduler implements scheduler.AuctionStartService interface.
+// It opens a pending auction for bidding once its start time is reached.
+func (client *AuctionService) StartAuctionForScheduler(ctx context.Context, auctionID uuid.UUID) (*auction.Auction, error) {
+	auction, err := client.auctionRepo.GetByID(ctx, auctionID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to retrieve auction for start")
+		return nil, err
+	}
+
+	if !auction.IsPending() {
+		client.logger.Warn().Str("auction_id", auctionID.String()).Str("status", string(auction.Status)).Msg("Auction is not pending")
+		return nil, shared.ErrAuctionStatusChanged
+	}
+
+	if time.Now().Before(auction.StartTime) {
+		return nil, shared.ErrAuctionNotStarted
+	}
+
+	previousStatus := auction.Status
+	auction.Start()
+	if err := client.auctionRepo.UpdateStatusWithOCC(ctx, auctionID, previousStatus, auction.Status); err != nil {
+		client.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to start auction")
+		return nil, err
+	}
+
+	client.logger.Info().Str("auction_id", auctionID.String()).Msg("Auction started")
+	return auction, nil
+}
+
+// DropPriceForScheduler implements scheduler.AuctionPriceDropService interface.
+// It lowers a Dutch auction to its scheduled price and reports whether the price changed.
+func (client *AuctionService) DropPriceForScheduler(ctx context.Context, auctionID uuid.UUID) (*auction.Auction, bool, error) {
+	auction, err := client.auctionRepo.GetByID(ctx, auctionID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to retrieve auction for price drop")
+		return nil, false, err
+	}
+
+	if !auction.IsDutch() {
+		return nil, false, shared.ErrUnsupportedAuctionType
+	}
+
+	if !auction.IsActive() {
+		return nil, false, shared.ErrAuctionNotAcceptingBids
+	}
+
+	newPrice := auction.DutchPriceAt(time.Now())
+	if !newPrice.LessThan(auction.CurrentPrice) {
+		return auction, false, nil
+	}
+
+	// Fails if a buyer accepted the price in the meantime
+	if err := client.auctionRepo.UpdatePriceWithOCC(ctx, auctionID, auction.CurrentPrice, newPrice); err != nil {
+		client.logger.Warn().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to drop auction price")
+		return nil, false, err
+	}
+
+	client.logger.Info().
+		Str("auction_id", auctionID.String()).
+		Stringer("previous_price", auction.CurrentPrice).
+		Stringer("current_price", newPrice).
+		Msg("Auction price dropped")
+
+	auction.CurrentPrice = newPrice
+	auction.UpdatedAt = time.Now()
+	return auction, true, nil
+}
+
+// RelistAuctionForScheduler puts an auction that ended unsold back up for sale as a new
+// auction for the same item, linked to the original and priced by its relist policy
+func (client *AuctionService) RelistAuctionForScheduler(ctx context.Context, auctionID uuid.UUID) (*auction.Auction, error) {
+	original, err := client.auctionRepo.GetByID(ctx, auctionID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to retrieve auction for relisting")
+		return nil, err
+	}
+
+	if !original.IsEnded() || !original.CanRelist() {
+		return nil, shared.ErrAuctionNotRelistable
+	}
+
+	now := time.Now()
+	relisted := original.Relist(now)
+
+	if err := client.auctionRepo.Create(ctx, relisted); err != nil {
+		client.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to save relisted auction")
+		return nil, err
+	}
+
+	if client.scheduler != nil {
+		if err := client.scheduler.ScheduleAuction(relisted.ID, relisted.EndTime); err != nil {
+			client.logger.Error().Err(err).Str("auction_id", relisted.ID.String()).Msg("Failed to schedule relisted auction for expiration")
+		}
+
+		if nextDrop, ok := relisted.NextPriceDropAt(now); ok {
+			if err := client.scheduler.SchedulePriceDrop(relisted.ID, nextDrop); err != nil {
+				client.logger.Error().Err(err).Str("auction_id", relisted.ID.String()).Msg("Failed to schedule price drop")
+			}
+		}
+	}
+
+	client.logger.Info().
+		Str("auction_id", auctionID.String()).
+		Str("relisted_auction_id", relisted.ID.String()).
+		Int("relist_count", relisted.RelistCount).
+		Stringer("starting_price", relisted.StartingPrice).
+		Msg("Unsold auction relisted")
+
+	return relisted, nil
+}
+
 // EndAuctionForScheduler implements scheduler.AuctionEndService interface
 func (client *AuctionService) EndAuctionForScheduler(ctx context.Context, auctionID uuid.UUID) (*shared.AuctionEndResult, error) {
-	return client.endAuctionWithResult(ctx, auctionID)
+	result, err := client.endAuctionWithResult(ctx, auctionID)
+	if err != nil {
+		return nil, err
+	}
+
+	client.openOrders(ctx, result)
+	return result, nil
+}
+
+// openOrders hands the winners of an ended auction over to settlement
+func (client *AuctionService) openOrders(ctx context.Context, result *shared.AuctionEndResult) {
+	if client.settlement == nil {
+		return
+	}
+
+	client.settlement.OpenOrders(ctx, result)
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"troffee-auction-service/internal/adapters/scheduler"
+	"troffee-auction-service/internal/domain/auction"
 	"troffee-auction-service/internal/domain/bid"
 	"troffee-auction-service/internal/domain/shared"
 	"troffee-auction-service/internal/ports/inbound"
@@ -15,29 +17,45 @@ import (
 
 // BidService implements the bid use cases
 type BidService struct {
-	bidRepo     outbound.BidRepository
-	auctionRepo outbound.AuctionRepository
-	userRepo    outbound.UserRepository
-	broadcaster outbound.Broadcaster
-	logger      zerolog.Logger
+	bidRepo         outbound.BidRepository
+	auctionRepo     outbound.AuctionRepository
+	userRepo        outbound.UserRepository
+	broadcaster     outbound.Broadcaster
+	scheduler       *scheduler.AuctionScheduler
+	settlement      *SettlementService
+	fxRates         outbound.FXRateProvider
+	buyNowThreshold float64
+	retractWindow   time.Duration
+	logger          zerolog.Logger
 }
 
 type BidServiceParams struct {
-	BidRepo     outbound.BidRepository
-	AuctionRepo outbound.AuctionRepository
-	UserRepo    outbound.UserRepository
-	Broadcaster outbound.Broadcaster
-	Logger      zerolog.Logger
+	BidRepo         outbound.BidRepository
+	AuctionRepo     outbound.AuctionRepository
+	UserRepo        outbound.UserRepository
+	Broadcaster     outbound.Broadcaster
+	Scheduler       *scheduler.AuctionScheduler
+	Settlement      *SettlementService
+	FXRates         outbound.FXRateProvider // converts bids and open exposure into the currency of credit limits
+	BuyNowThreshold float64
+	// RetractWindow is how long after placing it a bidder may retract their own bid
+	RetractWindow time.Duration
+	Logger        zerolog.Logger
 }
 
 // NewBidService creates a new bid service
 func NewBidService(params BidServiceParams) *BidService {
 	return &BidService{
-		bidRepo:     params.BidRepo,
-		auctionRepo: params.AuctionRepo,
-		userRepo:    params.UserRepo,
-		broadcaster: params.Broadcaster,
-		logger:      params.Logger.With().Str("component", "bid_service").Logger(),
+		bidRepo:         params.BidRepo,
+		auctionRepo:     params.AuctionRepo,
+		userRepo:        params.UserRepo,
+		broadcaster:     params.Broadcaster,
+		scheduler:       params.Scheduler,
+		settlement:      params.Settlement,
+		fxRates:         params.FXRates,
+		buyNowThreshold: params.BuyNowThreshold,
+		retractWindow:   params.RetractWindow,
+		logger:          params.Logger.With().Str("component", "bid_service").Logger(),
 	}
 }
 
@@ -46,85 +64,102 @@ func (client *BidService) PlaceBid(ctx context.Context, req inbound.PlaceBidRequ
 	client.logger.Info().
 		Str("auction_id", req.AuctionID.String()).
 		Str("user_id", req.UserID.String()).
-		Float64("amount", req.Amount).
+		Stringer("amount", req.Amount).
 		Msg("Attempting to place bid")
 
-	// Check if client is subscribed to the auction
-	if client.broadcaster != nil {
-		isSubscribed := client.broadcaster.IsSubscribed(ctx, req.AuctionID, req.ClientID)
-		if !isSubscribed {
-			client.logger.Warn().
-				Str("client_id", req.ClientID).
-				Str("user_id", req.UserID.String()).
-				Str("auction_id", req.AuctionID.String()).
-				Msg("Client not subscribed to auction")
-			return nil, shared.ErrUserNotSubscribed
-		}
+	auction, user, err := client.validateBidder(ctx, req.AuctionID, req.UserID, req.ClientID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Validate auction exists and is active
-	auction, err := client.auctionRepo.GetByID(ctx, req.AuctionID)
-	if err != nil {
-		client.logger.Error().Err(err).Str("auction_id", req.AuctionID.String()).Msg("Auction not found")
-		return nil, shared.ErrAuctionNotFound
+	// Dutch auctions are won by accepting the price, not by bidding
+	if auction.IsDutch() {
+		client.logger.Warn().Str("auction_id", req.AuctionID.String()).Msg("Bids are not supported for Dutch auctions")
+		return nil, shared.ErrUnsupportedAuctionType
 	}
-	if !auction.CanBid() {
-		client.logger.Warn().Str("auction_id", req.AuctionID.String()).Msg("Auction not accepting bids")
-		return nil, shared.ErrAuctionNotAcceptingBids
+
+	if err := client.checkBidCurrency(auction, req.Currency); err != nil {
+		return nil, err
 	}
-	if !auction.AuctionStarted() {
-		client.logger.Warn().Str("auction_id", req.AuctionID.String()).Msg("Auction not started")
-		return nil, shared.ErrAuctionNotStarted
+	req.Amount = auction.InCurrency(req.Amount)
+
+	// Validate bid amount
+	if !req.Amount.IsPositive() {
+		client.logger.Warn().Stringer("amount", req.Amount).Msg("Invalid bid amount (must be > 0)")
+		return nil, shared.ErrBidAmountInvalid
 	}
 
-	// Validate user exists
-	user, err := client.userRepo.GetByID(ctx, req.UserID)
-	if err != nil {
-		client.logger.Error().Err(err).Str("user_id", req.UserID.String()).Msg("User not found")
-		return nil, shared.ErrUserNotFound
+	if req.Quantity == 0 {
+		req.Quantity = 1
+	}
+	if req.Quantity < 0 || req.Quantity > auction.Quantity {
+		client.logger.Warn().Int("quantity", req.Quantity).Int("auction_quantity", auction.Quantity).Msg("Invalid bid quantity")
+		return nil, shared.ErrInvalidBidQuantity
 	}
 
-	client.logger.Debug().Str("user_id", user.ID.String()).Str("name", user.Name).Msg("User validated")
+	if err := client.checkCreditLimit(ctx, auction, user, req.Amount.Mul(req.Quantity)); err != nil {
+		return nil, err
+	}
 
-	// Validate bid amount
-	if req.Amount <= 0 {
-		client.logger.Warn().Float64("amount", req.Amount).Msg("Invalid bid amount (must be > 0)")
-		return nil, shared.ErrBidAmountInvalid
+	// Sealed bids only have to clear the starting price
+	if auction.IsSealed() {
+		return client.placeSealedBid(ctx, auction, user, req.Amount)
 	}
 
-	// Get current highest bid
-	highestBid, err := client.bidRepo.GetHighestBid(ctx, req.AuctionID)
+	if auction.IsMultiUnit() {
+		return client.placeMultiUnitBid(ctx, auction, user, req.Amount, req.Quantity)
+	}
+
+	// Get current best bid (the lowest one for reverse auctions)
+	bestBid, err := client.bidRepo.GetBestBid(ctx, req.AuctionID, auction.LowestBidWins())
 	if err != nil && err != shared.ErrNoBidsFound {
-		client.logger.Error().Err(err).Str("auction_id", req.AuctionID.String()).Msg("Failed to get highest bid")
+		client.logger.Error().Err(err).Str("auction_id", req.AuctionID.String()).Msg("Failed to get best bid")
 		return nil, err
 	}
 
-	// Validate bid is higher than current highest bid
-	if highestBid != nil && req.Amount <= highestBid.Amount {
+	// Validate bid beats the current best bid
+	if bestBid != nil && !auction.Outbids(req.Amount, bestBid.Amount) {
 		client.logger.Warn().
 			Str("auction_id", req.AuctionID.String()).
-			Float64("current_highest_bid", highestBid.Amount).
-			Float64("new_bid_amount", req.Amount).
-			Msg("Bid amount too low (must be higher than current highest bid)")
+			Stringer("current_best_bid", bestBid.Amount).
+			Stringer("new_bid_amount", req.Amount).
+			Msg("Bid amount does not beat current best bid")
+		if auction.LowestBidWins() {
+			return nil, shared.ErrBidAmountTooHigh
+		}
 		return nil, shared.ErrBidAmountTooLow
 	}
 
-	// Validate bid is higher than starting price if no previous bids
-	if highestBid == nil && req.Amount <= auction.StartingPrice {
+	// Validate bid beats the starting price if no previous bids
+	if bestBid == nil && !auction.Outbids(req.Amount, auction.StartingPrice) {
 		client.logger.Warn().
 			Str("auction_id", req.AuctionID.String()).
-			Float64("starting_price", auction.StartingPrice).
-			Float64("new_bid_amount", req.Amount).
-			Msg("Bid amount below starting price")
+			Stringer("starting_price", auction.StartingPrice).
+			Stringer("new_bid_amount", req.Amount).
+			Msg("Bid amount does not beat starting price")
+		if auction.LowestBidWins() {
+			return nil, shared.ErrBidAmountAboveStarting
+		}
 		return nil, shared.ErrBidAmountBelowStarting
 	}
 
+	// Validate bid beats the current price by the auction's increment
+	if !auction.MeetsIncrement(req.Amount) {
+		client.logger.Warn().
+			Str("auction_id", req.AuctionID.String()).
+			Stringer("next_bid", auction.NextBid()).
+			Stringer("new_bid_amount", req.Amount).
+			Msg("Bid amount does not meet the minimum increment")
+		return nil, shared.NewNextBidError(auction.NextBid(), auction.LowestBidWins())
+	}
+
 	// Create new bid
 	newBid := &bid.Bid{
 		ID:        uuid.New(),
 		AuctionID: req.AuctionID,
 		UserID:    user.ID,
 		Amount:    req.Amount,
+		Quantity:  req.Quantity,
 		Status:    bid.StatusAccepted,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
@@ -134,7 +169,7 @@ func (client *BidService) PlaceBid(ctx context.Context, req inbound.PlaceBidRequ
 
 	// Use optimistic concurrency control for bid placement
 	// This ensures strong consistency as required
-	err = client.placeBidWithOCC(ctx, newBid, auction.CurrentPrice)
+	proxyBids, extension, err := client.placeBidWithOCC(ctx, newBid, auction.CurrentPrice)
 	if err != nil {
 		client.logger.Error().Err(err).Str("bid_id", newBid.ID.String()).Msg("Failed to place bid with OCC")
 		return nil, err
@@ -158,56 +193,664 @@ func (client *BidService) PlaceBid(ctx context.Context, req inbound.PlaceBidRequ
 				Msg("User subscribed to auction after successful bid")
 		}
 	}
-	// Broadcast the new bid
+
+	// Broadcast the new bid followed by any automatic proxy responses
+	client.publishBidPlaced(ctx, auction, newBid, newBid.Amount)
+	for _, proxyBid := range proxyBids {
+		client.publishBidPlaced(ctx, auction, proxyBid, proxyBid.Amount)
+	}
+
+	client.announceExtension(ctx, req.AuctionID, extension)
+
+	return newBid, nil
+}
+
+// placeSealedBid records a hidden bid. Nothing is broadcast until the auction closes.
+func (client *BidService) placeSealedBid(ctx context.Context, auction *auction.Auction, user *shared.User, amount shared.Money) (*bid.Bid, error) {
+	if !amount.GreaterThan(auction.StartingPrice) {
+		client.logger.Warn().
+			Str("auction_id", auction.ID.String()).
+			Stringer("starting_price", auction.StartingPrice).
+			Stringer("new_bid_amount", amount).
+			Msg("Sealed bid below starting price")
+		return nil, shared.ErrBidAmountBelowStarting
+	}
+
+	now := time.Now()
+	sealedBid := &bid.Bid{
+		ID:        uuid.New(),
+		AuctionID: auction.ID,
+		UserID:    user.ID,
+		Amount:    amount,
+		Quantity:  1,
+		Status:    bid.StatusAccepted,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := client.bidRepo.PlaceSealedBid(ctx, sealedBid); err != nil {
+		client.logger.Error().Err(err).Str("bid_id", sealedBid.ID.String()).Msg("Failed to place sealed bid")
+		return nil, err
+	}
+
+	client.logger.Info().
+		Str("bid_id", sealedBid.ID.String()).
+		Str("auction_id", auction.ID.String()).
+		Str("user_id", user.ID.String()).
+		Msg("Sealed bid placed")
+
+	return sealedBid, nil
+}
+
+// placeMultiUnitBid places a bid for units of a multi-unit auction.
+// The auction price tracks the entry price: the lowest bid still winning units once all are taken.
+func (client *BidService) placeMultiUnitBid(ctx context.Context, auction *auction.Auction, user *shared.User, amount shared.Money, quantity int) (*bid.Bid, error) {
+	now := time.Now()
+	newBid := &bid.Bid{
+		ID:        uuid.New(),
+		AuctionID: auction.ID,
+		UserID:    user.ID,
+		Amount:    amount,
+		Quantity:  quantity,
+		Status:    bid.StatusAccepted,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	entryPrice, extension, err := client.bidRepo.PlaceMultiUnitBid(ctx, newBid)
+	if err != nil {
+		client.logger.Error().Err(err).Str("bid_id", newBid.ID.String()).Msg("Failed to place multi-unit bid")
+		return nil, err
+	}
+
+	client.logger.Info().
+		Str("bid_id", newBid.ID.String()).
+		Str("auction_id", auction.ID.String()).
+		Int("quantity", quantity).
+		Stringer("entry_price", entryPrice).
+		Msg("Multi-unit bid placed")
+
+	client.publishBidPlaced(ctx, auction, newBid, entryPrice)
+	client.announceExtension(ctx, auction.ID, extension)
+
+	return newBid, nil
+}
+
+// PlaceMaxBid stores a hidden maximum bid and lets the system bid on the user's behalf.
+// Only the resulting visible bids are broadcast; the maximum itself is never published.
+func (client *BidService) PlaceMaxBid(ctx context.Context, req inbound.PlaceMaxBidRequest) (*bid.ProxyBid, error) {
+	client.logger.Info().
+		Str("auction_id", req.AuctionID.String()).
+		Str("user_id", req.UserID.String()).
+		Msg("Attempting to place max bid")
+
+	auction, user, err := client.validateBidder(ctx, req.AuctionID, req.UserID, req.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !auction.IsEnglish() || auction.IsMultiUnit() {
+		client.logger.Warn().Str("auction_id", req.AuctionID.String()).Msg("Max bids are only supported for single-unit English auctions")
+		return nil, shared.ErrUnsupportedAuctionType
+	}
+
+	if err := client.checkBidCurrency(auction, req.Currency); err != nil {
+		return nil, err
+	}
+	req.MaxAmount = auction.InCurrency(req.MaxAmount)
+
+	if !req.MaxAmount.IsPositive() {
+		client.logger.Warn().Str("auction_id", req.AuctionID.String()).Msg("Invalid max bid amount (must be > 0)")
+		return nil, shared.ErrBidAmountInvalid
+	}
+
+	highestBid, err := client.bidRepo.GetBestBid(ctx, req.AuctionID, false)
+	if err != nil && err != shared.ErrNoBidsFound {
+		client.logger.Error().Err(err).Str("auction_id", req.AuctionID.String()).Msg("Failed to get highest bid")
+		return nil, err
+	}
+
+	if highestBid == nil && !req.MaxAmount.GreaterThan(auction.StartingPrice) {
+		client.logger.Warn().Str("auction_id", req.AuctionID.String()).Msg("Max bid below starting price")
+		return nil, shared.ErrBidAmountBelowStarting
+	}
+
+	if !req.MaxAmount.GreaterThan(auction.CurrentPrice) {
+		client.logger.Warn().Str("auction_id", req.AuctionID.String()).Msg("Max bid too low (must be higher than current price)")
+		return nil, shared.ErrBidAmountTooLow
+	}
+
+	if err := client.checkCreditLimit(ctx, auction, user, req.MaxAmount); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	proxy := &bid.ProxyBid{
+		ID:        uuid.New(),
+		AuctionID: req.AuctionID,
+		UserID:    user.ID,
+		MaxAmount: req.MaxAmount,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	placedBids, extension, err := client.bidRepo.PlaceMaxBidWithOCC(ctx, proxy, auction.CurrentPrice)
+	if err != nil {
+		client.logger.Error().Err(err).Str("auction_id", req.AuctionID.String()).Msg("Failed to place max bid with OCC")
+		return nil, err
+	}
+
+	for _, placedBid := range placedBids {
+		client.publishBidPlaced(ctx, auction, placedBid, placedBid.Amount)
+	}
+	client.announceExtension(ctx, req.AuctionID, extension)
+
+	client.logger.Info().
+		Str("auction_id", req.AuctionID.String()).
+		Str("user_id", req.UserID.String()).
+		Int("visible_bids", len(placedBids)).
+		Msg("Max bid placed successfully")
+
+	return proxy, nil
+}
+
+// BuyNow ends the auction immediately for the buyer at its buy-now price
+func (client *BidService) BuyNow(ctx context.Context, req inbound.BuyNowRequest) (*shared.AuctionEndResult, error) {
+	client.logger.Info().
+		Str("auction_id", req.AuctionID.String()).
+		Str("user_id", req.UserID.String()).
+		Msg("Attempting to buy now")
+
+	auction, user, err := client.validateBidder(ctx, req.AuctionID, req.UserID, req.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !auction.BuyNowAvailable(client.buyNowThreshold) {
+		client.logger.Warn().
+			Str("auction_id", req.AuctionID.String()).
+			Stringer("current_price", auction.CurrentPrice).
+			Msg("Buy now not available")
+		return nil, shared.ErrBuyNowUnavailable
+	}
+
+	// The buy-now price is committed at once, so it has to fit the credit limit
+	if err := client.checkCreditLimit(ctx, auction, user, *auction.BuyNowPrice); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	winningBid := &bid.Bid{
+		ID:        uuid.New(),
+		AuctionID: req.AuctionID,
+		UserID:    user.ID,
+		Amount:    *auction.BuyNowPrice,
+		Quantity:  1,
+		Status:    bid.StatusAccepted,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := client.bidRepo.PlaceWinningBidWithOCC(ctx, winningBid, auction.CurrentPrice); err != nil {
+		client.logger.Error().Err(err).Str("auction_id", req.AuctionID.String()).Msg("Failed to place buy now bid with OCC")
+		return nil, err
+	}
+
+	// The auction is over, it must not be picked up by the expiration scheduler
+	if client.scheduler != nil {
+		if err := client.scheduler.UnscheduleAuction(req.AuctionID); err != nil {
+			client.logger.Error().Err(err).Str("auction_id", req.AuctionID.String()).Msg("Failed to unschedule bought auction")
+		}
+	}
+
+	auction.EndAuction()
+	result := &shared.AuctionEndResult{
+		AuctionID: req.AuctionID,
+		Winners: []shared.AuctionWinner{{
+			UserID:   winningBid.UserID,
+			BidID:    winningBid.ID,
+			Quantity: winningBid.Quantity,
+			Price:    winningBid.Amount,
+		}},
+		Status: string(auction.Status),
+		Reason: shared.EndReasonBuyNow,
+	}
+
+	client.publishAuctionEnded(ctx, result)
+	if client.settlement != nil {
+		client.settlement.OpenOrders(ctx, result)
+	}
+
+	client.logger.Info().
+		Str("auction_id", req.AuctionID.String()).
+		Str("winner_id", winningBid.UserID.String()).
+		Stringer("final_price", winningBid.Amount).
+		Msg("Auction bought now")
+
+	return result, nil
+}
+
+// AcceptPrice ends a Dutch auction immediately for the buyer at its current price
+func (client *BidService) AcceptPrice(ctx context.Context, req inbound.AcceptPriceRequest) (*shared.AuctionEndResult, error) {
+	client.logger.Info().
+		Str("auction_id", req.AuctionID.String()).
+		Str("user_id", req.UserID.String()).
+		Msg("Attempting to accept price")
+
+	auction, user, err := client.validateBidder(ctx, req.AuctionID, req.UserID, req.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !auction.IsDutch() {
+		client.logger.Warn().Str("auction_id", req.AuctionID.String()).Msg("Accepting the price is only supported for Dutch auctions")
+		return nil, shared.ErrUnsupportedAuctionType
+	}
+
+	if err := client.checkCreditLimit(ctx, auction, user, auction.CurrentPrice); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	winningBid := &bid.Bid{
+		ID:        uuid.New(),
+		AuctionID: req.AuctionID,
+		UserID:    user.ID,
+		Amount:    auction.CurrentPrice,
+		Quantity:  1,
+		Status:    bid.StatusAccepted,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	// Fails if the price dropped or another buyer accepted first
+	if err := client.bidRepo.PlaceWinningBidWithOCC(ctx, winningBid, auction.CurrentPrice); err != nil {
+		client.logger.Error().Err(err).Str("auction_id", req.AuctionID.String()).Msg("Failed to accept price with OCC")
+		return nil, err
+	}
+
+	// The auction is over, it must not be picked up by the scheduler again
+	if client.scheduler != nil {
+		if err := client.scheduler.UnscheduleAuction(req.AuctionID); err != nil {
+			client.logger.Error().Err(err).Str("auction_id", req.AuctionID.String()).Msg("Failed to unschedule accepted auction")
+		}
+	}
+
+	auction.EndAuction()
+	result := &shared.AuctionEndResult{
+		AuctionID: req.AuctionID,
+		Winners: []shared.AuctionWinner{{
+			UserID:   winningBid.UserID,
+			BidID:    winningBid.ID,
+			Quantity: winningBid.Quantity,
+			Price:    winningBid.Amount,
+		}},
+		Status: string(auction.Status),
+		Reason: shared.EndReasonPriceAccepted,
+	}
+
+	client.publishAuctionEnded(ctx, result)
+	if client.settlement != nil {
+		client.settlement.OpenOrders(ctx, result)
+	}
+
+	client.logger.Info().
+		Str("auction_id", req.AuctionID.String()).
+		Str("winner_id", winningBid.UserID.String()).
+		Stringer("final_price", winningBid.Amount).
+		Msg("Auction price accepted")
+
+	return result, nil
+}
+
+// RetractBid withdraws an accepted bid on an active auction. Bidders may retract their own
+// bid within the retraction window, admins at any time; a reason is always required.
+// The auction price falls back to the best remaining bid and the retraction is audited.
+func (client *BidService) RetractBid(ctx context.Context, req inbound.RetractBidRequest) (*bid.Retraction, error) {
+	client.logger.Info().
+		Str("auction_id", req.AuctionID.String()).
+		Str("bid_id", req.BidID.String()).
+		Str("user_id", req.UserID.String()).
+		Msg("Attempting to retract bid")
+
+	if req.Reason == "" {
+		return nil, shared.ErrRetractReasonRequired
+	}
+
+	user, err := client.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("user_id", req.UserID.String()).Msg("User not found")
+		return nil, shared.ErrUserNotFound
+	}
+
+	retracted, err := client.bidRepo.GetByID(ctx, req.BidID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("bid_id", req.BidID.String()).Msg("Failed to retrieve bid for retraction")
+		return nil, err
+	}
+
+	if retracted.AuctionID != req.AuctionID {
+		return nil, shared.ErrBidNotFound
+	}
+
+	if !retracted.IsAccepted() {
+		client.logger.Warn().Str("bid_id", req.BidID.String()).Str("status", string(retracted.Status)).Msg("Bid cannot be retracted")
+		return nil, shared.ErrBidNotRetractable
+	}
+
+	now := time.Now()
+	if !user.IsAdmin() {
+		if retracted.UserID != user.ID {
+			client.logger.Warn().Str("bid_id", req.BidID.String()).Str("user_id", req.UserID.String()).Msg("User may not retract this bid")
+			return nil, shared.ErrNotBidOwner
+		}
+		if !retracted.WithinRetractionWindow(client.retractWindow, now) {
+			client.logger.Warn().
+				Str("bid_id", req.BidID.String()).
+				Time("placed_at", retracted.CreatedAt).
+				Dur("retract_window", client.retractWindow).
+				Msg("Bid retraction window has closed")
+			return nil, shared.ErrRetractionWindowClosed
+		}
+	}
+
+	auction, err := client.auctionRepo.GetByID(ctx, req.AuctionID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("auction_id", req.AuctionID.String()).Msg("Failed to retrieve auction for retraction")
+		return nil, err
+	}
+
+	retraction := &bid.Retraction{
+		ID:          uuid.New(),
+		BidID:       retracted.ID,
+		AuctionID:   retracted.AuctionID,
+		RetractedBy: user.ID,
+		Reason:      req.Reason,
+		CreatedAt:   now,
+	}
+
+	if err := client.bidRepo.RetractBid(ctx, retraction); err != nil {
+		client.logger.Error().Err(err).Str("bid_id", req.BidID.String()).Msg("Failed to retract bid")
+		return nil, err
+	}
+
+	client.logger.Info().
+		Str("auction_id", req.AuctionID.String()).
+		Str("bid_id", req.BidID.String()).
+		Str("retracted_by", user.ID.String()).
+		Stringer("previous_price", retraction.PreviousPrice).
+		Stringer("new_price", retraction.NewPrice).
+		Msg("Bid retracted")
+
+	client.publishBidRetracted(ctx, auction, retracted, retraction)
+
+	return retraction, nil
+}
+
+// publishBidRetracted announces a retraction and the price bidding resumes from.
+// Amounts and prices of sealed auctions stay hidden.
+func (client *BidService) publishBidRetracted(ctx context.Context, auction *auction.Auction, retracted *bid.Bid, retraction *bid.Retraction) {
+	if client.broadcaster == nil {
+		return
+	}
+
+	eventData := map[string]interface{}{
+		"bid_id":       retracted.ID,
+		"user_id":      retracted.UserID,
+		"retracted_by": retraction.RetractedBy,
+		"reason":       retraction.Reason,
+	}
+	if !auction.IsSealed() {
+		eventData["amount"] = retracted.Amount
+		eventData["current_price"] = retraction.NewPrice
+		if auction.LowestBidWins() {
+			eventData["max_next_bid"] = auction.NextBidAt(retraction.NewPrice)
+		} else {
+			eventData["min_next_bid"] = auction.NextBidAt(retraction.NewPrice)
+		}
+	}
+
+	event := outbound.Event{
+		Type:      outbound.EventTypeBidRetracted,
+		AuctionID: retraction.AuctionID,
+		Data:      eventData,
+		Timestamp: retraction.CreatedAt.Unix(),
+	}
+
+	if err := client.broadcaster.Publish(ctx, retraction.AuctionID, event); err != nil {
+		client.logger.Error().Err(err).Str("bid_id", retracted.ID.String()).Msg("Failed to broadcast bid retracted event")
+	}
+}
+
+// validateBidder checks the client subscription, auction state and user before bidding
+func (client *BidService) validateBidder(ctx context.Context, auctionID, userID uuid.UUID, clientID string) (*auction.Auction, *shared.User, error) {
+	// WebSocket clients must be subscribed to the auction; HTTP requests carry no client ID
+	if client.broadcaster != nil && clientID != "" {
+		isSubscribed := client.broadcaster.IsSubscribed(ctx, auctionID, clientID)
+		if !isSubscribed {
+			client.logger.Warn().
+				Str("client_id", clientID).
+				Str("user_id", userID.String()).
+				Str("auction_id", auctionID.String()).
+				Msg("Client not subscribed to auction")
+			return nil, nil, shared.ErrUserNotSubscribed
+		}
+	}
+
+	// Validate auction exists and is active
+	auction, err := client.auctionRepo.GetByID(ctx, auctionID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Auction not found")
+		return nil, nil, shared.ErrAuctionNotFound
+	}
+	// Pending auctions are opened by the scheduler at their start time
+	if auction.IsPending() {
+		client.logger.Warn().Str("auction_id", auctionID.String()).Msg("Auction not started")
+		return nil, nil, shared.ErrAuctionNotStarted
+	}
+	if !auction.CanBid() {
+		client.logger.Warn().Str("auction_id", auctionID.String()).Msg("Auction not accepting bids")
+		return nil, nil, shared.ErrAuctionNotAcceptingBids
+	}
+
+	// Validate user exists
+	user, err := client.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("user_id", userID.String()).Msg("User not found")
+		return nil, nil, shared.ErrUserNotFound
+	}
+
+	client.logger.Debug().Str("user_id", user.ID.String()).Str("name", user.Name).Msg("User validated")
+
+	return auction, user, nil
+}
+
+// checkBidCurrency rejects a bid denominated in a currency other than the auction's.
+// An unset currency means the bid is in the auction's currency.
+func (client *BidService) checkBidCurrency(auction *auction.Auction, currency shared.Currency) error {
+	if currency == "" || currency == auction.Currency {
+		return nil
+	}
+	client.logger.Warn().
+		Str("auction_id", auction.ID.String()).
+		Str("auction_currency", string(auction.Currency)).
+		Str("bid_currency", string(currency)).
+		Msg("Bid currency does not match the auction currency")
+	return shared.ErrCurrencyMismatch
+}
+
+// checkCreditLimit rejects a bid that would take the user's open exposure across active auctions
+// past their credit limit. The bid repository repeats the check atomically when placing the bid.
+func (client *BidService) checkCreditLimit(ctx context.Context, auction *auction.Auction, user *shared.User, amount shared.Money) error {
+	// Suppliers in reverse auctions are paid, so their bids do not use credit
+	if user.CreditLimit == nil || auction.LowestBidWins() {
+		return nil
+	}
+
+	exposure, err := client.bidRepo.GetOpenExposure(ctx, user.ID, auction.ID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to get open exposure")
+		return err
+	}
+
+	var rate shared.RateFunc
+	if client.fxRates != nil {
+		rate = func(from, to shared.Currency) (float64, error) {
+			return client.fxRates.Rate(ctx, from, to)
+		}
+	}
+
+	if err := user.CheckCredit(exposure, amount, rate); err != nil {
+		client.logger.Warn().Err(err).
+			Str("auction_id", auction.ID.String()).
+			Str("user_id", user.ID.String()).
+			Stringer("credit_limit", *user.CreditLimit).
+			Stringer("amount", amount).
+			Msg("Bid rejected by credit check")
+		return err
+	}
+
+	return nil
+}
+
+// publishBidPlaced broadcasts a visible bid to the auction subscribers, along with
+// the amount the next bid has to reach given the auction price after this bid
+func (client *BidService) publishBidPlaced(ctx context.Context, auction *auction.Auction, placedBid *bid.Bid, priceAfter shared.Money) {
+	if client.broadcaster == nil {
+		return
+	}
+
+	eventData := map[string]interface{}{
+		"bid_id":    placedBid.ID,
+		"user_id":   placedBid.UserID,
+		"amount":    placedBid.Amount,
+		"currency":  auction.Currency,
+		"quantity":  placedBid.Quantity,
+		"timestamp": placedBid.CreatedAt.Unix(),
+	}
+	if auction.LowestBidWins() {
+		eventData["max_next_bid"] = auction.NextBidAt(priceAfter)
+	} else {
+		eventData["min_next_bid"] = auction.NextBidAt(priceAfter)
+	}
+
 	event := outbound.Event{
 		Type:      outbound.EventTypeBidPlaced,
-		AuctionID: req.AuctionID,
-		Data: map[string]interface{}{
-			"bid_id":    newBid.ID,
-			"user_id":   newBid.UserID,
-			"amount":    newBid.Amount,
-			"timestamp": newBid.CreatedAt.Unix(),
-		},
-		Timestamp: newBid.CreatedAt.Unix(),
+		AuctionID: placedBid.AuctionID,
+		Data:      eventData,
+		Timestamp: placedBid.CreatedAt.Unix(),
 	}
 
-	if err := client.broadcaster.Publish(ctx, req.AuctionID, event); err != nil {
+	if err := client.broadcaster.Publish(ctx, placedBid.AuctionID, event); err != nil {
 		// Log error but don't fail the bid placement
-		client.logger.Error().Err(err).Str("bid_id", newBid.ID.String()).Msg("Failed to broadcast bid event")
+		client.logger.Error().Err(err).Str("bid_id", placedBid.ID.String()).Msg("Failed to broadcast bid event")
 	} else {
 		client.logger.Info().
-			Str("bid_id", newBid.ID.String()).
-			Str("auction_id", newBid.AuctionID.String()).
-			Str("user_id", newBid.UserID.String()).
-			Float64("amount", newBid.Amount).
+			Str("bid_id", placedBid.ID.String()).
+			Str("auction_id", placedBid.AuctionID.String()).
+			Str("user_id", placedBid.UserID.String()).
+			Stringer("amount", placedBid.Amount).
 			Msg("Bid placed successfully and broadcasted")
 	}
+}
 
-	return newBid, nil
+// publishAuctionEnded broadcasts an auction result to the auction subscribers
+func (client *BidService) publishAuctionEnded(ctx context.Context, result *shared.AuctionEndResult) {
+	if client.broadcaster == nil {
+		return
+	}
+
+	if err := client.broadcaster.Publish(ctx, result.AuctionID, outbound.NewAuctionEndedEvent(result)); err != nil {
+		client.logger.Error().Err(err).Str("auction_id", result.AuctionID.String()).Msg("Failed to broadcast auction end event")
+	}
+}
+
+// announceExtension reschedules and broadcasts the soft-close extension a bid caused.
+// The new end time was already persisted in the bid's transaction; failures are logged only.
+func (client *BidService) announceExtension(ctx context.Context, auctionID uuid.UUID, extension *auction.Extension) {
+	if extension == nil {
+		return
+	}
+
+	if client.scheduler != nil {
+		if err := client.scheduler.ScheduleAuction(auctionID, extension.EndTime); err != nil {
+			client.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to reschedule extended auction")
+		}
+	}
+
+	client.logger.Info().
+		Str("auction_id", auctionID.String()).
+		Time("previous_end_time", extension.PreviousEndTime).
+		Time("end_time", extension.EndTime).
+		Msg("Auction extended by late bid")
+
+	if client.broadcaster == nil {
+		return
+	}
+
+	event := outbound.Event{
+		Type:      outbound.EventTypeAuctionExtended,
+		AuctionID: auctionID,
+		Data: map[string]interface{}{
+			"auction_id":        auctionID.String(),
+			"end_time":          extension.EndTime.Format(time.RFC3339),
+			"previous_end_time": extension.PreviousEndTime.Format(time.RFC3339),
+		},
+		Timestamp: time.Now().Unix(),
+	}
+
+	if err := client.broadcaster.Publish(ctx, auctionID, event); err != nil {
+		client.logger.Error().Err(err).Str("auction_id", auctionID.String()).Msg("Failed to broadcast auction extended event")
+	}
+}
+
+// SetScheduler sets the auction scheduler used to reschedule extended auctions
+func (client *BidService) SetScheduler(scheduler *scheduler.AuctionScheduler) {
+	client.scheduler = scheduler
 }
 
 // placeBidWithOCC places a bid using optimistic concurrency control
-func (s *BidService) placeBidWithOCC(ctx context.Context, newBid *bid.Bid, currentPrice float64) error {
+func (s *BidService) placeBidWithOCC(ctx context.Context, newBid *bid.Bid, currentPrice shared.Money) ([]*bid.Bid, *auction.Extension, error) {
 	s.logger.Debug().
 		Str("bid_id", newBid.ID.String()).
-		Float64("current_price", currentPrice).
+		Stringer("current_price", currentPrice).
 		Msg("Attempting to place bid with OCC")
 
 	// Use the repository's OCC method directly through the interface
-	if err := s.bidRepo.PlaceBidWithOCC(ctx, newBid, currentPrice); err != nil {
+	proxyBids, extension, err := s.bidRepo.PlaceBidWithOCC(ctx, newBid, currentPrice)
+	if err != nil {
 		s.logger.Error().Err(err).Str("bid_id", newBid.ID.String()).Msg("Failed to place bid with OCC")
-		return err
+		return nil, nil, err
 	}
-	s.logger.Info().Str("bid_id", newBid.ID.String()).Msg("Bid placed successfully using OCC")
-	return nil
+	s.logger.Info().Str("bid_id", newBid.ID.String()).Int("proxy_bids", len(proxyBids)).Msg("Bid placed successfully using OCC")
+	return proxyBids, extension, nil
 }
 
-// GetBids retrieves bids for an auction
+// GetBids retrieves bids for an auction. Sealed bids stay hidden until the auction is over.
 func (s *BidService) GetBids(ctx context.Context, auctionID uuid.UUID) ([]*bid.Bid, error) {
+	auction, err := s.auctionRepo.GetByID(ctx, auctionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if auction.IsSealed() && (auction.IsPending() || auction.IsActive()) {
+		s.logger.Debug().Str("auction_id", auctionID.String()).Msg("Sealed bids are hidden until the auction ends")
+		return nil, shared.ErrSealedBidsHidden
+	}
+
 	return s.bidRepo.GetByAuctionID(ctx, auctionID)
 }
 
-// GetHighestBid retrieves the highest bid for an auction
-func (s *BidService) GetHighestBid(ctx context.Context, auctionID uuid.UUID) (*bid.Bid, error) {
-	return s.bidRepo.GetHighestBid(ctx, auctionID)
+// GetBestBid retrieves the currently winning bid for an auction. Sealed bids stay hidden until the auction is over.
+func (s *BidService) GetBestBid(ctx context.Context, auctionID uuid.UUID) (*bid.Bid, error) {
+	auction, err := s.auctionRepo.GetByID(ctx, auctionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if auction.IsSealed() && (auction.IsPending() || auction.IsActive()) {
+		return nil, shared.ErrSealedBidsHidden
+	}
+
+	return s.bidRepo.GetBestBid(ctx, auctionID, auction.LowestBidWins())
 }
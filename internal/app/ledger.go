@@ -0,0 +1,83 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"troffee-auction-service/internal/domain/ledger"
+	"troffee-auction-service/internal/domain/order"
+	"troffee-auction-service/internal/domain/shared"
+	"troffee-auction-service/internal/ports/inbound"
+	"troffee-auction-service/internal/ports/outbound"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// LedgerService records settled money movements and answers balance and statement queries
+type LedgerService struct {
+	ledgerRepo outbound.LedgerRepository
+	userRepo   outbound.UserRepository
+	logger     zerolog.Logger
+}
+type LedgerServiceParams struct {
+	LedgerRepo outbound.LedgerRepository
+	UserRepo   outbound.UserRepository
+	Logger     zerolog.Logger
+}
+
+// NewLedgerService creates a new ledger service
+func NewLedgerService(params LedgerServiceParams) *LedgerService {
+	return &LedgerService{
+		ledgerRepo: params.LedgerRepo,
+		userRepo:   params.UserRepo,
+		logger:     params.Logger.With().Str("component", "ledger_service").Logger(),
+	}
+}
+
+// SettlementTransaction builds the ledger transaction recording the payment of an order, its fees
+// and the seller's payout. It is posted together with the order's paid status.
+func (client *LedgerService) SettlementTransaction(paid *order.Order) *ledger.Transaction {
+	return ledger.NewSettlementTransaction(ledger.Settlement{
+		OrderID:      paid.ID,
+		AuctionID:    paid.AuctionID,
+		BuyerID:      paid.BuyerID,
+		SellerID:     paid.SellerID,
+		HammerPrice:  paid.HammerPrice,
+		BuyerPremium: paid.BuyerPremium,
+		SellerFee:    paid.SellerFee,
+	}, time.Now())
+}
+
+// GetBalances returns the balances of a user's account, one per currency the user has dealt in
+func (client *LedgerService) GetBalances(ctx context.Context, userID uuid.UUID) ([]*ledger.Balance, error) {
+	if _, err := client.userRepo.GetByID(ctx, userID); err != nil {
+		client.logger.Error().Err(err).Str("user_id", userID.String()).Msg("User not found")
+		return nil, shared.ErrUserNotFound
+	}
+
+	balances, err := client.ledgerRepo.GetUserBalances(ctx, userID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to get balances")
+		return nil, err
+	}
+
+	return balances, nil
+}
+
+// GetStatement returns a page of the entries posted to a user's account, newest first
+func (client *LedgerService) GetStatement(ctx context.Context, req inbound.StatementRequest) ([]*ledger.Entry, error) {
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 20
+	}
+
+	if _, err := client.userRepo.GetByID(ctx, req.UserID); err != nil {
+		client.logger.Error().Err(err).Str("user_id", req.UserID.String()).Msg("User not found")
+		return nil, shared.ErrUserNotFound
+	}
+
+	return client.ledgerRepo.GetUserEntries(ctx, req.UserID, req.Page, req.PageSize)
+}
@@ -0,0 +1,332 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"troffee-auction-service/internal/domain/auction"
+	"troffee-auction-service/internal/domain/bid"
+	"troffee-auction-service/internal/domain/shared"
+	"troffee-auction-service/internal/ports/inbound"
+	"troffee-auction-service/internal/ports/outbound"
+
+	"github.com/google/uuid"
+)
+
+// OfferSecondChance lets the creator or an admin of an ended auction offer the item to the
+// best bidder after the winner and everyone offered before, at the price of their best bid.
+// When orders are settled, the winner's order must have expired or be overdue first.
+// Only one offer can be open at a time; expired offers are closed on the way.
+func (client *AuctionService) OfferSecondChance(ctx context.Context, req inbound.SecondChanceOfferRequest) (*auction.SecondChanceOffer, error) {
+	client.logger.Info().
+		Str("auction_id", req.AuctionID.String()).
+		Str("user_id", req.UserID.String()).
+		Msg("Attempting to make second-chance offer")
+
+	auction, err := client.auctionRepo.GetByID(ctx, req.AuctionID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("auction_id", req.AuctionID.String()).Msg("Failed to retrieve auction for second-chance offer")
+		return nil, err
+	}
+
+	user, err := client.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("user_id", req.UserID.String()).Msg("User not found")
+		return nil, shared.ErrUserNotFound
+	}
+
+	if auction.CreatorID != user.ID && !user.IsAdmin() {
+		client.logger.Warn().Str("auction_id", req.AuctionID.String()).Str("user_id", req.UserID.String()).Msg("User may not make second-chance offers for this auction")
+		return nil, shared.ErrNotAuctionOwner
+	}
+
+	if !auction.IsEnded() || auction.IsMultiUnit() {
+		client.logger.Warn().Str("auction_id", req.AuctionID.String()).Str("status", string(auction.Status)).Msg("Second-chance offers are not available for this auction")
+		return nil, shared.ErrSecondChanceUnavailable
+	}
+
+	offers, err := client.offerRepo.GetByAuctionID(ctx, req.AuctionID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("auction_id", req.AuctionID.String()).Msg("Failed to get second-chance offers")
+		return nil, err
+	}
+
+	now := time.Now()
+	offered := make(map[uuid.UUID]bool, len(offers))
+	for _, previous := range offers {
+		offered[previous.UserID] = true
+
+		if previous.IsAccepted() {
+			return nil, shared.ErrSecondChanceAlreadyAccepted
+		}
+		if previous.IsOpenAt(now) {
+			return nil, shared.ErrSecondChanceOfferOpen
+		}
+		if previous.ExpiredAt(now) {
+			client.expireOffer(ctx, previous)
+		}
+	}
+
+	bids, err := client.bidRepo.GetByAuctionID(ctx, req.AuctionID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("auction_id", req.AuctionID.String()).Msg("Failed to get bids for second-chance offer")
+		return nil, err
+	}
+
+	// The first ranked bidder is the winner who defaulted
+	ranked := bid.RankBids(bids, auction.LowestBidWins())
+	if len(ranked) == 0 || !auction.ReserveMetBy(ranked[0].Amount) {
+		client.logger.Warn().Str("auction_id", req.AuctionID.String()).Msg("Auction ended without a winner")
+		return nil, shared.ErrSecondChanceUnavailable
+	}
+
+	if client.settlement != nil {
+		defaulted, err := client.settlement.WinnerDefaulted(ctx, req.AuctionID, ranked[0].UserID)
+		if err != nil {
+			client.logger.Error().Err(err).Str("auction_id", req.AuctionID.String()).Msg("Failed to get the winner's order for second-chance offer")
+			return nil, err
+		}
+		if !defaulted {
+			client.logger.Warn().Str("auction_id", req.AuctionID.String()).Str("winner_id", ranked[0].UserID.String()).Msg("Winner has not defaulted on the order")
+			return nil, shared.ErrWinnerNotDefaulted
+		}
+	}
+
+	var runnerUp *bid.Bid
+	for _, candidate := range ranked[1:] {
+		if !offered[candidate.UserID] {
+			runnerUp = candidate
+			break
+		}
+	}
+	if runnerUp == nil {
+		client.logger.Info().Str("auction_id", req.AuctionID.String()).Int("offers", len(offers)).Msg("No runner-up left for a second-chance offer")
+		return nil, shared.ErrNoRunnerUp
+	}
+
+	offer := auction.OfferSecondChance(runnerUp.ID, runnerUp.UserID, runnerUp.Amount, client.offerTTL, now)
+	// The store allows one pending or accepted offer per auction, so a concurrent offer fails here
+	if err := client.offerRepo.Create(ctx, offer); err != nil {
+		if errors.Is(err, shared.ErrSecondChanceOfferOpen) {
+			client.logger.Warn().Str("auction_id", req.AuctionID.String()).Msg("Another second-chance offer was made concurrently")
+			return nil, err
+		}
+		client.logger.Error().Err(err).Str("auction_id", req.AuctionID.String()).Msg("Failed to save second-chance offer")
+		return nil, err
+	}
+
+	if client.broadcaster != nil {
+		event := outbound.Event{
+			Type:      outbound.EventTypeSecondChanceOffered,
+			AuctionID: offer.AuctionID,
+			Data: map[string]interface{}{
+				"offer_id":   offer.ID.String(),
+				"auction_id": offer.AuctionID.String(),
+				"bid_id":     offer.BidID.String(),
+				"amount":     offer.Amount,
+				"expires_at": offer.ExpiresAt.Format(time.RFC3339),
+			},
+			Timestamp: now.Unix(),
+		}
+
+		if err := client.broadcaster.PublishToUser(ctx, offer.UserID, event); err != nil {
+			client.logger.Error().Err(err).Str("offer_id", offer.ID.String()).Msg("Failed to notify runner-up of second-chance offer")
+		}
+	}
+
+	client.logger.Info().
+		Str("auction_id", req.AuctionID.String()).
+		Str("offer_id", offer.ID.String()).
+		Str("runner_up_id", offer.UserID.String()).
+		Stringer("amount", offer.Amount).
+		Time("expires_at", offer.ExpiresAt).
+		Msg("Second-chance offer made")
+
+	return offer, nil
+}
+
+// AcceptSecondChanceOffer sells the item to the runner-up at the offered price. The winner's order
+// is expired first, so the item cannot be paid for twice. The sale is announced to auction
+// subscribers as an auction_ended result and to the seller directly.
+func (client *AuctionService) AcceptSecondChanceOffer(ctx context.Context, req inbound.SecondChanceAnswerRequest) (*shared.AuctionEndResult, error) {
+	offer, err := client.openOfferFor(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	auction, err := client.auctionRepo.GetByID(ctx, offer.AuctionID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("auction_id", offer.AuctionID.String()).Msg("Failed to retrieve auction for second-chance offer")
+		return nil, err
+	}
+
+	offers, err := client.offerRepo.GetByAuctionID(ctx, offer.AuctionID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("auction_id", offer.AuctionID.String()).Msg("Failed to get second-chance offers")
+		return nil, err
+	}
+	for _, other := range offers {
+		if other.IsAccepted() {
+			client.logger.Warn().Str("offer_id", offer.ID.String()).Str("accepted_offer_id", other.ID.String()).Msg("Item was already sold through another second-chance offer")
+			return nil, shared.ErrSecondChanceAlreadyAccepted
+		}
+	}
+
+	if client.settlement != nil {
+		if err := client.expireWinnerOrder(ctx, auction); err != nil {
+			return nil, err
+		}
+	}
+
+	// The store also refuses a second accepted offer for the auction, should one be accepted concurrently
+	previousStatus := offer.Status
+	offer.Accept()
+	if err := client.offerRepo.UpdateStatusWithOCC(ctx, offer.ID, previousStatus, offer.Status); err != nil {
+		client.logger.Error().Err(err).Str("offer_id", offer.ID.String()).Msg("Failed to accept second-chance offer")
+		return nil, err
+	}
+
+	result := &shared.AuctionEndResult{
+		AuctionID: offer.AuctionID,
+		Status:    string(auction.Status),
+		Reason:    shared.EndReasonSecondChance,
+		Winners: []shared.AuctionWinner{{
+			UserID:   offer.UserID,
+			BidID:    offer.BidID,
+			Quantity: 1,
+			Price:    offer.Amount,
+		}},
+	}
+
+	if client.broadcaster != nil {
+		if err := client.broadcaster.Publish(ctx, offer.AuctionID, outbound.NewAuctionEndedEvent(result)); err != nil {
+			client.logger.Error().Err(err).Str("auction_id", offer.AuctionID.String()).Msg("Failed to broadcast second-chance sale")
+		}
+	}
+	client.publishSecondChanceAnswered(ctx, auction.CreatorID, offer)
+	client.openOrders(ctx, result)
+
+	client.logger.Info().
+		Str("auction_id", offer.AuctionID.String()).
+		Str("offer_id", offer.ID.String()).
+		Str("winner_id", offer.UserID.String()).
+		Stringer("final_price", offer.Amount).
+		Msg("Second-chance offer accepted")
+
+	return result, nil
+}
+
+// DeclineSecondChanceOffer turns the offer down so the seller can offer the item to the next bidder
+func (client *AuctionService) DeclineSecondChanceOffer(ctx context.Context, req inbound.SecondChanceAnswerRequest) (*auction.SecondChanceOffer, error) {
+	offer, err := client.openOfferFor(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	auction, err := client.auctionRepo.GetByID(ctx, offer.AuctionID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("auction_id", offer.AuctionID.String()).Msg("Failed to retrieve auction for second-chance offer")
+		return nil, err
+	}
+
+	previousStatus := offer.Status
+	offer.Decline()
+	if err := client.offerRepo.UpdateStatusWithOCC(ctx, offer.ID, previousStatus, offer.Status); err != nil {
+		client.logger.Error().Err(err).Str("offer_id", offer.ID.String()).Msg("Failed to decline second-chance offer")
+		return nil, err
+	}
+
+	client.publishSecondChanceAnswered(ctx, auction.CreatorID, offer)
+
+	client.logger.Info().
+		Str("auction_id", offer.AuctionID.String()).
+		Str("offer_id", offer.ID.String()).
+		Str("user_id", offer.UserID.String()).
+		Msg("Second-chance offer declined")
+
+	return offer, nil
+}
+
+// expireWinnerOrder expires the order of an auction's winner ahead of a second-chance sale.
+// A winner who paid or can still pay keeps the item.
+func (client *AuctionService) expireWinnerOrder(ctx context.Context, auction *auction.Auction) error {
+	bids, err := client.bidRepo.GetByAuctionID(ctx, auction.ID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("auction_id", auction.ID.String()).Msg("Failed to get bids for second-chance sale")
+		return err
+	}
+
+	ranked := bid.RankBids(bids, auction.LowestBidWins())
+	if len(ranked) == 0 {
+		return nil
+	}
+
+	if err := client.settlement.ExpireDefaultedOrder(ctx, auction.ID, ranked[0].UserID); err != nil {
+		client.logger.Warn().Err(err).Str("auction_id", auction.ID.String()).Str("winner_id", ranked[0].UserID.String()).Msg("Winner's order could not be closed for second-chance sale")
+		return err
+	}
+	return nil
+}
+
+// openOfferFor returns the offer being answered if it was made to the user and can still be answered
+func (client *AuctionService) openOfferFor(ctx context.Context, req inbound.SecondChanceAnswerRequest) (*auction.SecondChanceOffer, error) {
+	offer, err := client.offerRepo.GetByID(ctx, req.OfferID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("offer_id", req.OfferID.String()).Msg("Failed to retrieve second-chance offer")
+		return nil, err
+	}
+
+	// Offers are private to their recipient
+	if offer.UserID != req.UserID {
+		client.logger.Warn().Str("offer_id", req.OfferID.String()).Str("user_id", req.UserID.String()).Msg("Second-chance offer was made to another user")
+		return nil, shared.ErrSecondChanceOfferNotFound
+	}
+
+	if offer.ExpiredAt(time.Now()) {
+		client.expireOffer(ctx, offer)
+		return nil, shared.ErrSecondChanceOfferExpired
+	}
+
+	if !offer.IsPending() {
+		return nil, shared.ErrSecondChanceOfferClosed
+	}
+
+	return offer, nil
+}
+
+// expireOffer closes an offer left unanswered past its expiry
+func (client *AuctionService) expireOffer(ctx context.Context, offer *auction.SecondChanceOffer) {
+	previousStatus := offer.Status
+	offer.Expire()
+	if err := client.offerRepo.UpdateStatusWithOCC(ctx, offer.ID, previousStatus, offer.Status); err != nil {
+		client.logger.Error().Err(err).Str("offer_id", offer.ID.String()).Msg("Failed to expire second-chance offer")
+		return
+	}
+
+	client.logger.Info().Str("offer_id", offer.ID.String()).Str("auction_id", offer.AuctionID.String()).Msg("Second-chance offer expired")
+}
+
+// publishSecondChanceAnswered tells the seller how the runner-up answered
+func (client *AuctionService) publishSecondChanceAnswered(ctx context.Context, sellerID uuid.UUID, offer *auction.SecondChanceOffer) {
+	if client.broadcaster == nil {
+		return
+	}
+
+	event := outbound.Event{
+		Type:      outbound.EventTypeSecondChanceAnswered,
+		AuctionID: offer.AuctionID,
+		Data: map[string]interface{}{
+			"offer_id":   offer.ID.String(),
+			"auction_id": offer.AuctionID.String(),
+			"user_id":    offer.UserID.String(),
+			"amount":     offer.Amount,
+			"status":     string(offer.Status),
+		},
+		Timestamp: offer.UpdatedAt.Unix(),
+	}
+
+	if err := client.broadcaster.PublishToUser(ctx, sellerID, event); err != nil {
+		client.logger.Error().Err(err).Str("offer_id", offer.ID.String()).Msg("Failed to notify seller of second-chance answer")
+	}
+}
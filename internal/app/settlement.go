@@ -0,0 +1,316 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"troffee-auction-service/internal/adapters/scheduler"
+	"troffee-auction-service/internal/domain/ledger"
+	"troffee-auction-service/internal/domain/order"
+	"troffee-auction-service/internal/domain/shared"
+	"troffee-auction-service/internal/ports/inbound"
+	"troffee-auction-service/internal/ports/outbound"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// SettlementService turns auction results into orders and collects their payment.
+// It implements inbound.SettlementService and scheduler.OrderExpiryService.
+type SettlementService struct {
+	orderRepo     outbound.OrderRepository
+	auctionRepo   outbound.AuctionRepository
+	gateway       outbound.PaymentGateway
+	broadcaster   outbound.Broadcaster
+	scheduler     *scheduler.AuctionScheduler
+	ledger        *LedgerService
+	paymentWindow time.Duration
+	buyerPremium  ledger.FeeSchedule
+	sellerFee     ledger.FeeSchedule
+	logger        zerolog.Logger
+}
+type SettlementServiceParams struct {
+	OrderRepo   outbound.OrderRepository
+	AuctionRepo outbound.AuctionRepository
+	Gateway     outbound.PaymentGateway
+	Broadcaster outbound.Broadcaster
+	Scheduler   *scheduler.AuctionScheduler
+	// Ledger records paid orders; nothing is posted when nil
+	Ledger *LedgerService
+	// PaymentWindow is how long a winner has to pay for an order
+	PaymentWindow time.Duration
+	// BuyerPremium is charged to the buyer on top of the hammer price
+	BuyerPremium ledger.FeeSchedule
+	// SellerFee is the commission taken out of the seller's payout
+	SellerFee ledger.FeeSchedule
+	Logger    zerolog.Logger
+}
+
+// NewSettlementService creates a new settlement service
+func NewSettlementService(params SettlementServiceParams) *SettlementService {
+	return &SettlementService{
+		orderRepo:     params.OrderRepo,
+		auctionRepo:   params.AuctionRepo,
+		gateway:       params.Gateway,
+		broadcaster:   params.Broadcaster,
+		scheduler:     params.Scheduler,
+		ledger:        params.Ledger,
+		paymentWindow: params.PaymentWindow,
+		buyerPremium:  params.BuyerPremium,
+		sellerFee:     params.SellerFee,
+		logger:        params.Logger.With().Str("component", "settlement_service").Logger(),
+	}
+}
+
+// SetScheduler sets the auction scheduler used to expire unpaid orders
+func (client *SettlementService) SetScheduler(scheduler *scheduler.AuctionScheduler) {
+	client.scheduler = scheduler
+}
+
+// OpenOrders creates an order awaiting payment for every winning bid of an auction and notifies each buyer.
+// Failures are logged only; the auction result stands either way.
+func (client *SettlementService) OpenOrders(ctx context.Context, result *shared.AuctionEndResult) {
+	if result == nil || !result.HasWinner() {
+		return
+	}
+
+	auction, err := client.auctionRepo.GetByID(ctx, result.AuctionID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("auction_id", result.AuctionID.String()).Msg("Failed to retrieve auction for settlement")
+		return
+	}
+
+	now := time.Now()
+	for _, winner := range result.Winners {
+		o := order.New(result.AuctionID, winner.BidID, winner.UserID, auction.CreatorID, winner.Quantity, auction.InCurrency(winner.Price), client.paymentWindow, now)
+		o.ApplyFees(client.buyerPremium.FeeFor(o.HammerPrice), client.sellerFee.FeeFor(o.HammerPrice))
+		if err := client.orderRepo.Create(ctx, o); err != nil {
+			client.logger.Error().Err(err).
+				Str("auction_id", result.AuctionID.String()).
+				Str("buyer_id", winner.UserID.String()).
+				Msg("Failed to create order")
+			continue
+		}
+
+		if client.scheduler != nil {
+			if err := client.scheduler.ScheduleOrderExpiry(o.ID, o.DueAt); err != nil {
+				client.logger.Error().Err(err).Str("order_id", o.ID.String()).Msg("Failed to schedule order expiry")
+			}
+		}
+
+		client.publishOrderEvent(ctx, outbound.EventTypeOrderCreated, o)
+
+		client.logger.Info().
+			Str("auction_id", result.AuctionID.String()).
+			Str("order_id", o.ID.String()).
+			Str("buyer_id", o.BuyerID.String()).
+			Stringer("hammer_price", o.HammerPrice).
+			Stringer("amount", o.Amount).
+			Time("due_at", o.DueAt).
+			Msg("Order opened")
+	}
+}
+
+// WinnerDefaulted returns true if the order opened for the buyer in an auction expired or is overdue
+func (client *SettlementService) WinnerDefaulted(ctx context.Context, auctionID, buyerID uuid.UUID) (bool, error) {
+	orders, err := client.orderRepo.GetByAuctionID(ctx, auctionID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, o := range orders {
+		if o.BuyerID == buyerID {
+			return o.IsDefaultedAt(time.Now()), nil
+		}
+	}
+	return false, nil
+}
+
+// ExpireDefaultedOrder expires the order opened for the buyer in an auction before the item is sold
+// to someone else, so that the buyer cannot pay for it afterwards. It fails with
+// ErrWinnerNotDefaulted when the buyer can still pay, or paid in the meantime.
+func (client *SettlementService) ExpireDefaultedOrder(ctx context.Context, auctionID, buyerID uuid.UUID) error {
+	orders, err := client.orderRepo.GetByAuctionID(ctx, auctionID)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range orders {
+		if o.BuyerID != buyerID || o.Status == order.StatusExpired {
+			continue
+		}
+		if !o.IsDefaultedAt(time.Now()) {
+			return shared.ErrWinnerNotDefaulted
+		}
+		if err := client.expireOrder(ctx, o); err != nil {
+			if errors.Is(err, shared.ErrOrderStatusChanged) {
+				return shared.ErrWinnerNotDefaulted
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// PayOrder charges the buyer for an order through the payment gateway. The order is claimed for
+// the attempt first, so it cannot expire while the gateway is charging the buyer. A declined
+// payment leaves the order open for another attempt until it is due.
+func (client *SettlementService) PayOrder(ctx context.Context, req inbound.PayOrderRequest) (*order.Order, error) {
+	client.logger.Info().
+		Str("order_id", req.OrderID.String()).
+		Str("user_id", req.UserID.String()).
+		Msg("Attempting to pay order")
+
+	o, err := client.orderRepo.GetByID(ctx, req.OrderID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("order_id", req.OrderID.String()).Msg("Failed to retrieve order")
+		return nil, err
+	}
+
+	// Orders are private to their buyer
+	if o.BuyerID != req.UserID {
+		client.logger.Warn().Str("order_id", req.OrderID.String()).Str("user_id", req.UserID.String()).Msg("Order belongs to another user")
+		return nil, shared.ErrOrderNotFound
+	}
+
+	if o.IsProcessing() {
+		return nil, shared.ErrOrderPaymentInProgress
+	}
+
+	if o.IsOverdueAt(time.Now()) {
+		client.expireOrder(ctx, o)
+		return nil, shared.ErrOrderExpired
+	}
+
+	if !o.CanBePaid() {
+		return nil, shared.ErrOrderNotPayable
+	}
+
+	// Expiry and concurrent attempts leave a processing order alone
+	claimedStatus := o.Status
+	o.StartPayment()
+	if err := client.orderRepo.UpdateWithOCC(ctx, o, claimedStatus); err != nil {
+		client.logger.Warn().Err(err).Str("order_id", o.ID.String()).Msg("Failed to claim order for payment")
+		return nil, err
+	}
+
+	previousStatus := o.Status
+	payment, chargeErr := client.gateway.Charge(ctx, outbound.PaymentRequest{
+		OrderID:     o.ID,
+		PayerID:     o.BuyerID,
+		PayeeID:     o.SellerID,
+		Amount:      o.Amount,
+		Description: fmt.Sprintf("Auction %s", o.AuctionID),
+	})
+
+	if chargeErr != nil {
+		o.FailPayment(chargeErr.Error())
+		if err := client.orderRepo.UpdateWithOCC(ctx, o, previousStatus); err != nil {
+			client.logger.Error().Err(err).Str("order_id", o.ID.String()).Msg("Failed to record failed payment")
+			return nil, err
+		}
+
+		client.publishOrderEvent(ctx, outbound.EventTypeOrderPaymentFailed, o)
+
+		client.logger.Warn().Err(chargeErr).
+			Str("order_id", o.ID.String()).
+			Stringer("amount", o.Amount).
+			Msg("Order payment failed")
+
+		if errors.Is(chargeErr, shared.ErrPaymentDeclined) {
+			return nil, shared.ErrPaymentDeclined
+		}
+		return nil, chargeErr
+	}
+
+	o.MarkPaid(payment.Reference, time.Now())
+
+	// The settlement is booked with the paid status, so a paid order never lacks its ledger entries
+	var settlement *ledger.Transaction
+	if client.ledger != nil {
+		settlement = client.ledger.SettlementTransaction(o)
+	}
+	if err := client.orderRepo.UpdatePaidWithOCC(ctx, o, previousStatus, settlement); err != nil {
+		// The charge went through; the order stays processing, so it neither expires nor is charged
+		// again, until it is reconciled with the gateway, which knows the charge by its order ID
+		client.logger.Error().Err(err).
+			Str("order_id", o.ID.String()).
+			Str("payment_reference", payment.Reference).
+			Msg("Failed to record payment")
+		return nil, err
+	}
+
+	if client.scheduler != nil {
+		if err := client.scheduler.UnscheduleOrderExpiry(o.ID); err != nil {
+			client.logger.Error().Err(err).Str("order_id", o.ID.String()).Msg("Failed to unschedule paid order")
+		}
+	}
+
+	client.publishOrderEvent(ctx, outbound.EventTypeOrderPaid, o)
+
+	client.logger.Info().
+		Str("order_id", o.ID.String()).
+		Str("buyer_id", o.BuyerID.String()).
+		Stringer("amount", o.Amount).
+		Str("payment_reference", o.PaymentReference).
+		Msg("Order paid")
+
+	return o, nil
+}
+
+// ExpireOrderForScheduler implements scheduler.OrderExpiryService interface.
+// It closes an order that was not paid within the payment window.
+func (client *SettlementService) ExpireOrderForScheduler(ctx context.Context, orderID uuid.UUID) (*order.Order, error) {
+	o, err := client.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("order_id", orderID.String()).Msg("Failed to retrieve order for expiry")
+		return nil, err
+	}
+
+	// The attempt in flight decides; the order is looked at again once it failed
+	if o.IsProcessing() {
+		return nil, shared.ErrOrderPaymentInProgress
+	}
+
+	if !o.CanBePaid() {
+		return nil, shared.ErrOrderNotPayable
+	}
+
+	if !o.IsOverdueAt(time.Now()) {
+		return nil, shared.ErrOrderNotDue
+	}
+
+	if err := client.expireOrder(ctx, o); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// expireOrder closes an order left unpaid past its due time and tells the buyer
+func (client *SettlementService) expireOrder(ctx context.Context, o *order.Order) error {
+	previousStatus := o.Status
+	o.Expire()
+	if err := client.orderRepo.UpdateWithOCC(ctx, o, previousStatus); err != nil {
+		client.logger.Error().Err(err).Str("order_id", o.ID.String()).Msg("Failed to expire order")
+		return err
+	}
+
+	client.publishOrderEvent(ctx, outbound.EventTypeOrderExpired, o)
+
+	client.logger.Info().Str("order_id", o.ID.String()).Str("auction_id", o.AuctionID.String()).Msg("Order expired")
+	return nil
+}
+
+// publishOrderEvent tells the buyer about a change to their order
+func (client *SettlementService) publishOrderEvent(ctx context.Context, eventType outbound.EventType, o *order.Order) {
+	if client.broadcaster == nil {
+		return
+	}
+
+	if err := client.broadcaster.PublishToUser(ctx, o.BuyerID, outbound.NewOrderEvent(eventType, o)); err != nil {
+		client.logger.Error().Err(err).Str("order_id", o.ID.String()).Str("event_type", string(eventType)).Msg("Failed to notify buyer of order")
+	}
+}
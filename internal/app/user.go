@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+
+	"troffee-auction-service/internal/domain/shared"
+	"troffee-auction-service/internal/ports/inbound"
+	"troffee-auction-service/internal/ports/outbound"
+
+	"github.com/rs/zerolog"
+)
+
+// UserService implements the user administration use cases
+type UserService struct {
+	userRepo outbound.UserRepository
+	logger   zerolog.Logger
+}
+type UserServiceParams struct {
+	UserRepo outbound.UserRepository
+	Logger   zerolog.Logger
+}
+
+// NewUserService creates a new user service
+func NewUserService(params UserServiceParams) *UserService {
+	return &UserService{
+		userRepo: params.UserRepo,
+		logger:   params.Logger.With().Str("component", "user_service").Logger(),
+	}
+}
+
+// SetCreditLimit lets an admin approve how much a user may have committed in winning bids at once.
+// A nil limit removes it. Bids already placed are left alone.
+func (client *UserService) SetCreditLimit(ctx context.Context, req inbound.SetCreditLimitRequest) (*shared.User, error) {
+	client.logger.Info().
+		Str("admin_id", req.AdminID.String()).
+		Str("user_id", req.UserID.String()).
+		Msg("Attempting to set credit limit")
+
+	admin, err := client.userRepo.GetByID(ctx, req.AdminID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("admin_id", req.AdminID.String()).Msg("User not found")
+		return nil, shared.ErrUserNotFound
+	}
+
+	if !admin.IsAdmin() {
+		client.logger.Warn().Str("user_id", req.AdminID.String()).Msg("Only admins can set credit limits")
+		return nil, shared.ErrNotAdmin
+	}
+
+	if req.CreditLimit != nil && req.CreditLimit.IsNegative() {
+		return nil, shared.ErrInvalidCreditLimit
+	}
+
+	user, err := client.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		client.logger.Error().Err(err).Str("user_id", req.UserID.String()).Msg("User not found")
+		return nil, shared.ErrUserNotFound
+	}
+
+	if err := client.userRepo.UpdateCreditLimit(ctx, user.ID, req.CreditLimit); err != nil {
+		client.logger.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to update credit limit")
+		return nil, err
+	}
+	user.CreditLimit = req.CreditLimit
+
+	logger := client.logger.Info().Str("admin_id", admin.ID.String()).Str("user_id", user.ID.String())
+	if user.CreditLimit != nil {
+		logger = logger.Stringer("credit_limit", *user.CreditLimit)
+	}
+	logger.Msg("Credit limit updated")
+
+	return user, nil
+}
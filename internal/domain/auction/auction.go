@@ -3,6 +3,8 @@ package auction
 import (
 	"time"
 
+	"troffee-auction-service/internal/domain/shared"
+
 	"github.com/google/uuid"
 )
 
@@ -16,18 +18,80 @@ const (
 	StatusCancelled Status = "cancelled"
 )
 
+// Type represents the auction format
+type Type string
+
+const (
+	// TypeEnglish is an ascending-price auction where the highest bid wins
+	TypeEnglish Type = "english"
+	// TypeDutch is a descending-price auction where the first buyer to accept the price wins
+	TypeDutch Type = "dutch"
+	// TypeSealedFirstPrice is a sealed-bid auction where the highest bidder pays their own bid
+	TypeSealedFirstPrice Type = "sealed_first_price"
+	// TypeSealedSecondPrice is a sealed-bid (Vickrey) auction where the highest bidder pays the second-highest bid
+	TypeSealedSecondPrice Type = "sealed_second_price"
+	// TypeReverse is a procurement auction where suppliers bid downward and the lowest bid wins
+	TypeReverse Type = "reverse"
+)
+
+// Pricing decides what the winners of a multi-unit auction pay
+type Pricing string
+
+const (
+	// PricingPayAsBid charges every winner their own bid
+	PricingPayAsBid Pricing = "pay_as_bid"
+	// PricingUniform charges every winner the lowest winning bid
+	PricingUniform Pricing = "uniform"
+)
+
+// LowestBidWins returns true if bids of this auction type compete downward
+func (t Type) LowestBidWins() bool {
+	return t == TypeReverse
+}
+
+// Outbids returns true if amount beats price under this auction type's ordering
+func (t Type) Outbids(amount, price shared.Money) bool {
+	if t.LowestBidWins() {
+		return amount.LessThan(price)
+	}
+	return amount.GreaterThan(price)
+}
+
 // Auction represents an auction for an item
 type Auction struct {
-	ID            uuid.UUID `json:"id"`
-	ItemID        uuid.UUID `json:"item_id"`
-	CreatorID     uuid.UUID `json:"creator_id"`
-	StartTime     time.Time `json:"start_time"`
-	EndTime       time.Time `json:"end_time"`
-	StartingPrice float64   `json:"starting_price"`
-	CurrentPrice  float64   `json:"current_price"`
-	Status        Status    `json:"status"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID               uuid.UUID       `json:"id"`
+	ItemID           uuid.UUID       `json:"item_id"`
+	CreatorID        uuid.UUID       `json:"creator_id"`
+	StartTime        time.Time       `json:"start_time"`
+	EndTime          time.Time       `json:"end_time"`
+	StartingPrice    shared.Money    `json:"starting_price"`
+	CurrentPrice     shared.Money    `json:"current_price"`
+	Status           Status          `json:"status"`
+	Type             Type            `json:"type"`
+	Currency         shared.Currency `json:"currency"`
+	SoftCloseSeconds int             `json:"soft_close_seconds"`
+	ReservePrice     *shared.Money   `json:"-"`
+	BuyNowPrice      *shared.Money   `json:"buy_now_price,omitempty"`
+
+	// Dutch auction price schedule
+	PriceDropAmount          shared.Money `json:"price_drop_amount"`
+	PriceDropIntervalSeconds int          `json:"price_drop_interval_seconds,omitempty"`
+	FloorPrice               shared.Money `json:"floor_price"`
+
+	// IncrementRule is the minimum step between bids; DefaultBidIncrement applies when unset
+	IncrementRule *IncrementRule `json:"increment_rule,omitempty"`
+
+	// Quantity is the number of identical units sold; Pricing only applies when it is above 1
+	Quantity int     `json:"quantity"`
+	Pricing  Pricing `json:"pricing,omitempty"`
+
+	// RelistPolicy relists the auction when it ends unsold; RelistedFrom links a relist to the auction it replaces
+	RelistPolicy *RelistPolicy `json:"relist_policy,omitempty"`
+	RelistedFrom *uuid.UUID    `json:"relisted_from,omitempty"`
+	RelistCount  int           `json:"relist_count"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // IsActive returns true if the auction is currently active
@@ -35,6 +99,106 @@ func (a *Auction) IsActive() bool {
 	return a.Status == StatusActive
 }
 
+// IsDutch returns true if the auction is a descending-price (Dutch) auction
+func (a *Auction) IsDutch() bool {
+	return a.Type == TypeDutch
+}
+
+// IsEnglish returns true if the auction is an ascending-price (English) auction
+func (a *Auction) IsEnglish() bool {
+	return a.Type == TypeEnglish
+}
+
+// IsSealed returns true if bids stay hidden until the auction closes
+func (a *Auction) IsSealed() bool {
+	return a.Type == TypeSealedFirstPrice || a.Type == TypeSealedSecondPrice
+}
+
+// PriceHidden returns true while a sealed auction is open: its current price and whether
+// the reserve is met would hint at the hidden bids
+func (a *Auction) PriceHidden() bool {
+	return a.IsSealed() && !a.IsEnded()
+}
+
+// LowestBidWins returns true if the auction is a reverse auction
+func (a *Auction) LowestBidWins() bool {
+	return a.Type.LowestBidWins()
+}
+
+// Outbids returns true if amount beats price in this auction
+func (a *Auction) Outbids(amount, price shared.Money) bool {
+	return a.Type.Outbids(amount, price)
+}
+
+// IncrementAt returns the minimum step between bids at the given price
+func (a *Auction) IncrementAt(price shared.Money) shared.Money {
+	return a.IncrementRule.IncrementAt(price)
+}
+
+// NextBidAt returns the amount a bid must reach to beat price: at least price plus
+// the increment, or at most price minus the increment for reverse auctions
+func (a *Auction) NextBidAt(price shared.Money) shared.Money {
+	if a.LowestBidWins() {
+		return price.Sub(a.IncrementAt(price))
+	}
+	return price.Add(a.IncrementAt(price))
+}
+
+// NextBid returns the amount the next bid must reach to beat the current price
+func (a *Auction) NextBid() shared.Money {
+	return a.NextBidAt(a.CurrentPrice)
+}
+
+// MeetsIncrement returns true if amount beats the current price by at least the increment
+func (a *Auction) MeetsIncrement(amount shared.Money) bool {
+	if a.LowestBidWins() {
+		return !amount.GreaterThan(a.NextBid())
+	}
+	return !amount.LessThan(a.NextBid())
+}
+
+// SetCurrency sets the currency of the auction and tags every price with it
+func (a *Auction) SetCurrency(currency shared.Currency) {
+	a.Currency = currency
+	a.StartingPrice = a.StartingPrice.WithCurrency(currency)
+	a.CurrentPrice = a.CurrentPrice.WithCurrency(currency)
+	a.PriceDropAmount = a.PriceDropAmount.WithCurrency(currency)
+	a.FloorPrice = a.FloorPrice.WithCurrency(currency)
+	if a.ReservePrice != nil {
+		reserve := a.ReservePrice.WithCurrency(currency)
+		a.ReservePrice = &reserve
+	}
+	if a.BuyNowPrice != nil {
+		buyNow := a.BuyNowPrice.WithCurrency(currency)
+		a.BuyNowPrice = &buyNow
+	}
+}
+
+// InCurrency tags an amount, such as a bid read back from storage, with the auction's currency
+func (a *Auction) InCurrency(amount shared.Money) shared.Money {
+	return amount.WithCurrency(a.Currency)
+}
+
+// IsMultiUnit returns true if the auction sells several identical units
+func (a *Auction) IsMultiUnit() bool {
+	return a.Quantity > 1
+}
+
+// HasUniformPricing returns true if all winners of a multi-unit auction pay the lowest winning bid
+func (a *Auction) HasUniformPricing() bool {
+	return a.Pricing == PricingUniform
+}
+
+// IsPending returns true if the auction was created but has not started yet
+func (a *Auction) IsPending() bool {
+	return a.Status == StatusPending
+}
+
+// IsCancelled returns true if the auction was cancelled
+func (a *Auction) IsCancelled() bool {
+	return a.Status == StatusCancelled
+}
+
 // IsEnded returns true if the auction has ended
 func (a *Auction) IsEnded() bool {
 	return a.Status == StatusEnded
@@ -49,14 +213,134 @@ func (a *Auction) AuctionStarted() bool {
 	return a.StartTime.Before(time.Now())
 }
 
+// HasReserve returns true if the seller set a hidden reserve price
+func (a *Auction) HasReserve() bool {
+	return a.ReservePrice != nil
+}
+
+// ReserveMetBy returns true if the given price satisfies the reserve (always true without one)
+func (a *Auction) ReserveMetBy(price shared.Money) bool {
+	return a.ReservePrice == nil || !price.LessThan(*a.ReservePrice)
+}
+
+// ReserveMet returns true if the current price satisfies the reserve
+func (a *Auction) ReserveMet() bool {
+	return a.ReserveMetBy(a.CurrentPrice)
+}
+
+// BuyNowAvailable returns true if the auction can still be bought at its buy-now price.
+// The option is withdrawn once bidding reaches threshold (a fraction) of the buy-now price.
+func (a *Auction) BuyNowAvailable(threshold float64) bool {
+	if a.BuyNowPrice == nil || !a.IsActive() {
+		return false
+	}
+
+	// No bids yet, bids always exceed the starting price
+	if a.CurrentPrice.Equal(a.StartingPrice) {
+		return true
+	}
+
+	return a.CurrentPrice.LessThan(a.BuyNowPrice.Scale(threshold))
+}
+
+// DutchPriceAt returns the scheduled price of a Dutch auction at the given time,
+// dropping by PriceDropAmount every PriceDropIntervalSeconds until FloorPrice
+func (a *Auction) DutchPriceAt(t time.Time) shared.Money {
+	if !a.IsDutch() || a.PriceDropIntervalSeconds <= 0 || t.Before(a.StartTime) {
+		return a.StartingPrice
+	}
+
+	interval := time.Duration(a.PriceDropIntervalSeconds) * time.Second
+	drops := int(t.Sub(a.StartTime) / interval)
+
+	return shared.MaxMoney(a.StartingPrice.Sub(a.PriceDropAmount.Mul(drops)), a.FloorPrice)
+}
+
+// NextPriceDropAt returns when the price of a Dutch auction drops next after the given time.
+// It returns false once the floor price is reached.
+func (a *Auction) NextPriceDropAt(t time.Time) (time.Time, bool) {
+	if !a.IsDutch() || a.PriceDropIntervalSeconds <= 0 || !a.DutchPriceAt(t).GreaterThan(a.FloorPrice) {
+		return time.Time{}, false
+	}
+
+	interval := time.Duration(a.PriceDropIntervalSeconds) * time.Second
+	if t.Before(a.StartTime) {
+		return a.StartTime.Add(interval), true
+	}
+
+	drops := int(t.Sub(a.StartTime)/interval) + 1
+	return a.StartTime.Add(time.Duration(drops) * interval), true
+}
+
+// ClearingPrice returns what the winner of a sealed auction pays, given each bidder's
+// best amount ranked highest first. Second-price auctions charge the runner-up's bid,
+// or the starting or reserve price when that is higher.
+func (a *Auction) ClearingPrice(rankedAmounts []shared.Money) shared.Money {
+	if len(rankedAmounts) == 0 {
+		return a.CurrentPrice
+	}
+	if a.Type != TypeSealedSecondPrice {
+		return rankedAmounts[0]
+	}
+
+	price := a.StartingPrice
+	if len(rankedAmounts) > 1 {
+		price = shared.MaxMoney(price, rankedAmounts[1])
+	}
+	if a.ReservePrice != nil {
+		price = shared.MaxMoney(price, *a.ReservePrice)
+	}
+	return shared.MinMoney(price, rankedAmounts[0])
+}
+
 // UpdateCurrentPrice updates the current price of the auction
-func (a *Auction) UpdateCurrentPrice(newPrice float64) {
-	if newPrice > a.CurrentPrice {
+func (a *Auction) UpdateCurrentPrice(newPrice shared.Money) {
+	if a.Outbids(newPrice, a.CurrentPrice) {
 		a.CurrentPrice = newPrice
 		a.UpdatedAt = time.Now()
 	}
 }
 
+// Extension records a soft-close extension of an auction's end time
+type Extension struct {
+	PreviousEndTime time.Time
+	EndTime         time.Time
+}
+
+// ExtendForLateBid pushes EndTime forward when a bid lands inside the soft-close window
+// (the last SoftCloseSeconds of the auction, 0 disables it). It returns true if extended.
+func (a *Auction) ExtendForLateBid(bidTime time.Time) bool {
+	if a.SoftCloseSeconds <= 0 || bidTime.After(a.EndTime) {
+		return false
+	}
+
+	window := time.Duration(a.SoftCloseSeconds) * time.Second
+	if a.EndTime.Sub(bidTime) > window {
+		return false
+	}
+
+	newEndTime := bidTime.Add(window)
+	if !newEndTime.After(a.EndTime) {
+		return false
+	}
+
+	a.EndTime = newEndTime
+	a.UpdatedAt = time.Now()
+	return true
+}
+
+// Cancel withdraws the auction without a winner
+func (a *Auction) Cancel() {
+	a.Status = StatusCancelled
+	a.UpdatedAt = time.Now()
+}
+
+// Start opens a pending auction for bidding
+func (a *Auction) Start() {
+	a.Status = StatusActive
+	a.UpdatedAt = time.Now()
+}
+
 // EndAuction marks the auction as ended
 func (a *Auction) EndAuction() {
 	a.Status = StatusEnded
@@ -0,0 +1,143 @@
+package auction
+
+import (
+	"testing"
+	"time"
+
+	"troffee-auction-service/internal/domain/shared"
+)
+
+var epoch = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func usd(minor int64) shared.Money {
+	return shared.NewMoney(minor, "USD")
+}
+
+func usdPtr(minor int64) *shared.Money {
+	m := usd(minor)
+	return &m
+}
+
+func TestClearingPrice(t *testing.T) {
+	tests := []struct {
+		name    string
+		auction Auction
+		amounts []shared.Money
+		want    shared.Money
+	}{
+		{
+			name:    "no bids keeps the current price",
+			auction: Auction{Type: TypeSealedSecondPrice, StartingPrice: usd(5000), CurrentPrice: usd(5000)},
+			amounts: nil,
+			want:    usd(5000),
+		},
+		{
+			name:    "first price charges the winning bid",
+			auction: Auction{Type: TypeSealedFirstPrice, StartingPrice: usd(5000)},
+			amounts: []shared.Money{usd(12000), usd(9000)},
+			want:    usd(12000),
+		},
+		{
+			name:    "second price charges the runner-up",
+			auction: Auction{Type: TypeSealedSecondPrice, StartingPrice: usd(5000)},
+			amounts: []shared.Money{usd(12000), usd(9000)},
+			want:    usd(9000),
+		},
+		{
+			name:    "second price with a single bid charges the starting price",
+			auction: Auction{Type: TypeSealedSecondPrice, StartingPrice: usd(5000)},
+			amounts: []shared.Money{usd(12000)},
+			want:    usd(5000),
+		},
+		{
+			name:    "second price raised to the reserve",
+			auction: Auction{Type: TypeSealedSecondPrice, StartingPrice: usd(5000), ReservePrice: usdPtr(10000)},
+			amounts: []shared.Money{usd(12000), usd(9000)},
+			want:    usd(10000),
+		},
+		{
+			name:    "second price never above the winning bid",
+			auction: Auction{Type: TypeSealedSecondPrice, StartingPrice: usd(5000), ReservePrice: usdPtr(15000)},
+			amounts: []shared.Money{usd(12000), usd(9000)},
+			want:    usd(12000),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.auction.ClearingPrice(tt.amounts); !got.Equal(tt.want) {
+				t.Errorf("ClearingPrice = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtendForLateBid(t *testing.T) {
+	endTime := epoch.Add(time.Hour)
+
+	tests := []struct {
+		name        string
+		softClose   int
+		bidTime     time.Time
+		wantExtend  bool
+		wantEndTime time.Time
+	}{
+		{name: "soft close disabled", softClose: 0, bidTime: endTime.Add(-10 * time.Second), wantEndTime: endTime},
+		{name: "bid before the window", softClose: 60, bidTime: endTime.Add(-2 * time.Minute), wantEndTime: endTime},
+		{name: "bid at the window start", softClose: 60, bidTime: endTime.Add(-time.Minute), wantEndTime: endTime},
+		{name: "bid inside the window", softClose: 60, bidTime: endTime.Add(-30 * time.Second), wantExtend: true, wantEndTime: endTime.Add(30 * time.Second)},
+		{name: "bid at the end time", softClose: 60, bidTime: endTime, wantExtend: true, wantEndTime: endTime.Add(time.Minute)},
+		{name: "bid after the end time", softClose: 60, bidTime: endTime.Add(time.Second), wantEndTime: endTime},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Auction{EndTime: endTime, SoftCloseSeconds: tt.softClose}
+			if got := a.ExtendForLateBid(tt.bidTime); got != tt.wantExtend {
+				t.Errorf("ExtendForLateBid = %v, want %v", got, tt.wantExtend)
+			}
+			if !a.EndTime.Equal(tt.wantEndTime) {
+				t.Errorf("EndTime = %s, want %s", a.EndTime, tt.wantEndTime)
+			}
+		})
+	}
+}
+
+func TestDutchPriceAt(t *testing.T) {
+	dutch := Auction{
+		Type:                     TypeDutch,
+		StartTime:                epoch,
+		StartingPrice:            usd(10000),
+		PriceDropAmount:          usd(500),
+		PriceDropIntervalSeconds: 60,
+		FloorPrice:               usd(8000),
+	}
+	english := dutch
+	english.Type = TypeEnglish
+	unscheduled := dutch
+	unscheduled.PriceDropIntervalSeconds = 0
+
+	tests := []struct {
+		name    string
+		auction Auction
+		at      time.Time
+		want    shared.Money
+	}{
+		{name: "before the start", auction: dutch, at: epoch.Add(-time.Minute), want: usd(10000)},
+		{name: "at the start", auction: dutch, at: epoch, want: usd(10000)},
+		{name: "just before the first drop", auction: dutch, at: epoch.Add(59 * time.Second), want: usd(10000)},
+		{name: "first drop", auction: dutch, at: epoch.Add(time.Minute), want: usd(9500)},
+		{name: "between drops", auction: dutch, at: epoch.Add(150 * time.Second), want: usd(9000)},
+		{name: "stops at the floor", auction: dutch, at: epoch.Add(time.Hour), want: usd(8000)},
+		{name: "not a Dutch auction", auction: english, at: epoch.Add(time.Hour), want: usd(10000)},
+		{name: "no drop interval", auction: unscheduled, at: epoch.Add(time.Hour), want: usd(10000)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.auction.DutchPriceAt(tt.at); !got.Equal(tt.want) {
+				t.Errorf("DutchPriceAt = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
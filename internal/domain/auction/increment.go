@@ -0,0 +1,67 @@
+package auction
+
+import "troffee-auction-service/internal/domain/shared"
+
+// DefaultBidIncrement is the minimum step between bids when an auction has no increment rule
+var DefaultBidIncrement = shared.NewMoney(1, shared.DefaultCurrency)
+
+// IncrementTier sets the increment for prices below UpTo. Only the last tier may leave UpTo unset.
+type IncrementTier struct {
+	UpTo      *shared.Money `json:"up_to,omitempty"`
+	Increment shared.Money  `json:"increment"`
+}
+
+// IncrementRule defines the minimum step between bids, either flat or tiered by price
+type IncrementRule struct {
+	Flat  shared.Money    `json:"flat"`
+	Tiers []IncrementTier `json:"tiers,omitempty"`
+}
+
+// Valid returns true if the rule is either a positive flat increment or
+// a table of positive increments with ascending price bounds
+func (r *IncrementRule) Valid() bool {
+	if r.Flat.IsNegative() || r.Flat.IsPositive() == (len(r.Tiers) > 0) {
+		return false
+	}
+
+	var previousUpTo *shared.Money
+	for i, tier := range r.Tiers {
+		if !tier.Increment.IsPositive() {
+			return false
+		}
+		if tier.UpTo == nil {
+			if i != len(r.Tiers)-1 {
+				return false
+			}
+			continue
+		}
+		if !tier.UpTo.IsPositive() || (previousUpTo != nil && !tier.UpTo.GreaterThan(*previousUpTo)) {
+			return false
+		}
+		previousUpTo = tier.UpTo
+	}
+
+	return true
+}
+
+// IncrementAt returns the minimum step between bids at the given price.
+// Prices above the last bounded tier use that tier's increment.
+func (r *IncrementRule) IncrementAt(price shared.Money) shared.Money {
+	if r == nil {
+		return DefaultBidIncrement
+	}
+	if r.Flat.IsPositive() {
+		return r.Flat
+	}
+
+	for _, tier := range r.Tiers {
+		if tier.UpTo == nil || price.LessThan(*tier.UpTo) {
+			return tier.Increment
+		}
+	}
+	if len(r.Tiers) > 0 {
+		return r.Tiers[len(r.Tiers)-1].Increment
+	}
+
+	return DefaultBidIncrement
+}
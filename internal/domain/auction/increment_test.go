@@ -0,0 +1,67 @@
+package auction
+
+import (
+	"testing"
+
+	"troffee-auction-service/internal/domain/shared"
+)
+
+func TestIncrementRuleValid(t *testing.T) {
+	tests := []struct {
+		name string
+		rule IncrementRule
+		want bool
+	}{
+		{name: "flat", rule: IncrementRule{Flat: usd(100)}, want: true},
+		{name: "tiers", rule: IncrementRule{Tiers: []IncrementTier{{UpTo: usdPtr(10000), Increment: usd(100)}, {Increment: usd(500)}}}, want: true},
+		{name: "tiers ending bounded", rule: IncrementRule{Tiers: []IncrementTier{{UpTo: usdPtr(10000), Increment: usd(100)}}}, want: true},
+		{name: "empty", rule: IncrementRule{}, want: false},
+		{name: "negative flat", rule: IncrementRule{Flat: usd(-100)}, want: false},
+		{name: "flat and tiers", rule: IncrementRule{Flat: usd(100), Tiers: []IncrementTier{{Increment: usd(500)}}}, want: false},
+		{name: "zero tier increment", rule: IncrementRule{Tiers: []IncrementTier{{Increment: usd(0)}}}, want: false},
+		{name: "unbounded tier not last", rule: IncrementRule{Tiers: []IncrementTier{{Increment: usd(100)}, {UpTo: usdPtr(10000), Increment: usd(500)}}}, want: false},
+		{name: "bounds not ascending", rule: IncrementRule{Tiers: []IncrementTier{{UpTo: usdPtr(10000), Increment: usd(100)}, {UpTo: usdPtr(10000), Increment: usd(500)}}}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Valid(); got != tt.want {
+				t.Errorf("Valid = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncrementRuleIncrementAt(t *testing.T) {
+	tiered := &IncrementRule{Tiers: []IncrementTier{
+		{UpTo: usdPtr(10000), Increment: usd(100)},
+		{UpTo: usdPtr(50000), Increment: usd(500)},
+		{Increment: usd(2500)},
+	}}
+	bounded := &IncrementRule{Tiers: []IncrementTier{
+		{UpTo: usdPtr(10000), Increment: usd(100)},
+		{UpTo: usdPtr(50000), Increment: usd(500)},
+	}}
+
+	tests := []struct {
+		name  string
+		rule  *IncrementRule
+		price shared.Money
+		want  shared.Money
+	}{
+		{name: "no rule", rule: nil, price: usd(10000), want: DefaultBidIncrement},
+		{name: "flat", rule: &IncrementRule{Flat: usd(250)}, price: usd(99999), want: usd(250)},
+		{name: "first tier", rule: tiered, price: usd(9999), want: usd(100)},
+		{name: "tier bound is exclusive", rule: tiered, price: usd(10000), want: usd(500)},
+		{name: "unbounded tier", rule: tiered, price: usd(50000), want: usd(2500)},
+		{name: "above the last bounded tier", rule: bounded, price: usd(90000), want: usd(500)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.IncrementAt(tt.price); !got.Equal(tt.want) {
+				t.Errorf("IncrementAt(%s) = %s, want %s", tt.price, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package auction
+
+import (
+	"time"
+
+	"troffee-auction-service/internal/domain/shared"
+
+	"github.com/google/uuid"
+)
+
+// RelistPolicy puts an unsold auction back up for sale automatically. Every relist
+// lowers the prices by PriceDropPercent and runs for DurationSeconds.
+type RelistPolicy struct {
+	MaxRelists       int     `json:"max_relists"`
+	PriceDropPercent float64 `json:"price_drop_percent"`
+	DurationSeconds  int     `json:"duration_seconds"`
+}
+
+// Valid returns true if the policy allows at least one relist of a positive
+// duration and drops prices by less than 100%
+func (p *RelistPolicy) Valid() bool {
+	return p.MaxRelists > 0 && p.DurationSeconds > 0 &&
+		p.PriceDropPercent >= 0 && p.PriceDropPercent < 100
+}
+
+// CanRelist returns true if the auction has a relist policy with relists left
+func (a *Auction) CanRelist() bool {
+	return a.RelistPolicy != nil && a.RelistCount < a.RelistPolicy.MaxRelists
+}
+
+// Relist builds the follow-up auction for the same item, starting now. Starting,
+// reserve, buy-now and floor prices are lowered by the policy's percentage, which
+// keeps them in the same order relative to each other.
+func (a *Auction) Relist(now time.Time) *Auction {
+	factor := 1 - a.RelistPolicy.PriceDropPercent/100
+	lower := func(price shared.Money) shared.Money {
+		return shared.MaxMoney(price.Scale(factor), shared.NewMoney(1, price.Currency()))
+	}
+
+	relisted := *a
+	relisted.ID = uuid.New()
+	relisted.StartTime = now
+	relisted.EndTime = now.Add(time.Duration(a.RelistPolicy.DurationSeconds) * time.Second)
+	relisted.StartingPrice = lower(a.StartingPrice)
+	relisted.CurrentPrice = relisted.StartingPrice
+	relisted.Status = StatusActive
+	relisted.RelistedFrom = &a.ID
+	relisted.RelistCount = a.RelistCount + 1
+	relisted.CreatedAt = now
+	relisted.UpdatedAt = now
+
+	if a.ReservePrice != nil {
+		reserve := lower(*a.ReservePrice)
+		relisted.ReservePrice = &reserve
+	}
+	if a.BuyNowPrice != nil {
+		buyNow := lower(*a.BuyNowPrice)
+		relisted.BuyNowPrice = &buyNow
+	}
+	if a.IsDutch() {
+		relisted.FloorPrice = a.FloorPrice.Scale(factor)
+	}
+
+	return &relisted
+}
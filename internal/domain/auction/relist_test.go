@@ -0,0 +1,93 @@
+package auction
+
+import (
+	"testing"
+	"time"
+
+	"troffee-auction-service/internal/domain/shared"
+
+	"github.com/google/uuid"
+)
+
+func TestRelist(t *testing.T) {
+	now := epoch.Add(48 * time.Hour)
+	policy := &RelistPolicy{MaxRelists: 3, PriceDropPercent: 10, DurationSeconds: 3600}
+
+	tests := []struct {
+		name        string
+		auction     Auction
+		wantStart   shared.Money
+		wantReserve *shared.Money
+		wantBuyNow  *shared.Money
+		wantFloor   shared.Money
+	}{
+		{
+			name:      "starting price lowered",
+			auction:   Auction{Type: TypeEnglish, StartingPrice: usd(10000), CurrentPrice: usd(10000)},
+			wantStart: usd(9000),
+		},
+		{
+			name:        "reserve and buy now lowered",
+			auction:     Auction{Type: TypeEnglish, StartingPrice: usd(10000), ReservePrice: usdPtr(20000), BuyNowPrice: usdPtr(30000)},
+			wantStart:   usd(9000),
+			wantReserve: usdPtr(18000),
+			wantBuyNow:  usdPtr(27000),
+		},
+		{
+			name:      "Dutch floor lowered",
+			auction:   Auction{Type: TypeDutch, StartingPrice: usd(10000), FloorPrice: usd(5000)},
+			wantStart: usd(9000),
+			wantFloor: usd(4500),
+		},
+		{
+			name:      "prices stay positive",
+			auction:   Auction{Type: TypeEnglish, StartingPrice: usd(1)},
+			wantStart: usd(1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := tt.auction
+			original.ID = uuid.New()
+			original.Status = StatusEnded
+			original.RelistPolicy = policy
+			original.RelistCount = 1
+			original.CurrentPrice = usd(12345)
+
+			got := original.Relist(now)
+
+			if got.ID == original.ID || got.RelistedFrom == nil || *got.RelistedFrom != original.ID {
+				t.Errorf("relist %s from %v, want a new ID relisted from %s", got.ID, got.RelistedFrom, original.ID)
+			}
+			if got.RelistCount != 2 || got.Status != StatusActive {
+				t.Errorf("relist count %d status %s, want 2 active", got.RelistCount, got.Status)
+			}
+			if !got.StartTime.Equal(now) || !got.EndTime.Equal(now.Add(time.Hour)) {
+				t.Errorf("relist runs %s to %s, want %s to %s", got.StartTime, got.EndTime, now, now.Add(time.Hour))
+			}
+			if !got.StartingPrice.Equal(tt.wantStart) || !got.CurrentPrice.Equal(tt.wantStart) {
+				t.Errorf("starting %s current %s, want %s", got.StartingPrice, got.CurrentPrice, tt.wantStart)
+			}
+			assertPrice(t, "reserve", got.ReservePrice, tt.wantReserve)
+			assertPrice(t, "buy now", got.BuyNowPrice, tt.wantBuyNow)
+			if !got.FloorPrice.Equal(tt.wantFloor) {
+				t.Errorf("floor %s, want %s", got.FloorPrice, tt.wantFloor)
+			}
+			if original.ReservePrice != nil && got.ReservePrice == original.ReservePrice {
+				t.Error("relist shares the reserve price with the original auction")
+			}
+		})
+	}
+}
+
+func assertPrice(t *testing.T, name string, got, want *shared.Money) {
+	t.Helper()
+	switch {
+	case got == nil && want == nil:
+	case got == nil || want == nil:
+		t.Errorf("%s = %v, want %v", name, got, want)
+	case !got.Equal(*want):
+		t.Errorf("%s = %s, want %s", name, got, want)
+	}
+}
@@ -0,0 +1,87 @@
+package auction
+
+import (
+	"time"
+
+	"troffee-auction-service/internal/domain/shared"
+
+	"github.com/google/uuid"
+)
+
+// OfferStatus represents the state of a second-chance offer
+type OfferStatus string
+
+const (
+	OfferStatusPending  OfferStatus = "pending"
+	OfferStatusAccepted OfferStatus = "accepted"
+	OfferStatusDeclined OfferStatus = "declined"
+	OfferStatusExpired  OfferStatus = "expired"
+)
+
+// SecondChanceOffer offers the item of an ended auction to a runner-up at the
+// price of their best bid after the winner failed to pay
+type SecondChanceOffer struct {
+	ID        uuid.UUID    `json:"id"`
+	AuctionID uuid.UUID    `json:"auction_id"`
+	BidID     uuid.UUID    `json:"bid_id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	Amount    shared.Money `json:"amount"`
+	Status    OfferStatus  `json:"status"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+// OfferSecondChance creates a pending offer of the auction's item to the author of a
+// losing bid at the given price, expiring after ttl
+func (a *Auction) OfferSecondChance(bidID, userID uuid.UUID, amount shared.Money, ttl time.Duration, now time.Time) *SecondChanceOffer {
+	return &SecondChanceOffer{
+		ID:        uuid.New(),
+		AuctionID: a.ID,
+		BidID:     bidID,
+		UserID:    userID,
+		Amount:    amount,
+		Status:    OfferStatusPending,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// IsPending returns true if the offer has not been answered or marked expired
+func (o *SecondChanceOffer) IsPending() bool {
+	return o.Status == OfferStatusPending
+}
+
+// IsAccepted returns true if the runner-up accepted the offer
+func (o *SecondChanceOffer) IsAccepted() bool {
+	return o.Status == OfferStatusAccepted
+}
+
+// ExpiredAt returns true if the offer is still pending past its expiry
+func (o *SecondChanceOffer) ExpiredAt(now time.Time) bool {
+	return o.IsPending() && !now.Before(o.ExpiresAt)
+}
+
+// IsOpenAt returns true if the offer can still be answered at the given time
+func (o *SecondChanceOffer) IsOpenAt(now time.Time) bool {
+	return o.IsPending() && now.Before(o.ExpiresAt)
+}
+
+// Accept marks the offer as accepted
+func (o *SecondChanceOffer) Accept() {
+	o.Status = OfferStatusAccepted
+	o.UpdatedAt = time.Now()
+}
+
+// Decline marks the offer as declined
+func (o *SecondChanceOffer) Decline() {
+	o.Status = OfferStatusDeclined
+	o.UpdatedAt = time.Now()
+}
+
+// Expire marks the offer as expired
+func (o *SecondChanceOffer) Expire() {
+	o.Status = OfferStatusExpired
+	o.UpdatedAt = time.Now()
+}
@@ -0,0 +1,50 @@
+package auction
+
+import "troffee-auction-service/internal/domain/shared"
+
+// View is an auction as shown to bidders. The buy-now price is only shown while the auction can
+// still be bought at it, so the option disappears from every API once bidding withdraws it.
+type View struct {
+	*Auction
+	// CurrentPrice shadows the auction's own field, so it is left out while the auction's price is hidden
+	CurrentPrice *shared.Money `json:"current_price,omitempty"`
+
+	// BuyNowPrice shadows the auction's own field when marshalled
+	BuyNowPrice     *shared.Money `json:"buy_now_price,omitempty"`
+	BuyNowAvailable bool          `json:"buy_now_available"`
+
+	// The next acceptable bid: the lowest one, or the highest one for reverse auctions.
+	// Neither is set for sealed and Dutch auctions, which are not driven by increments.
+	MinNextBid *shared.Money `json:"min_next_bid,omitempty"`
+	MaxNextBid *shared.Money `json:"max_next_bid,omitempty"`
+}
+
+// NewView creates the view of an auction; buyNowThreshold is the BUY_NOW_THRESHOLD fraction
+func NewView(a *Auction, buyNowThreshold float64) *View {
+	view := &View{Auction: a}
+	if !a.PriceHidden() {
+		view.CurrentPrice = &a.CurrentPrice
+	}
+	if a.BuyNowAvailable(buyNowThreshold) {
+		view.BuyNowPrice = a.BuyNowPrice
+		view.BuyNowAvailable = true
+	}
+	if !a.IsSealed() && !a.IsDutch() {
+		nextBid := a.NextBid()
+		if a.LowestBidWins() {
+			view.MaxNextBid = &nextBid
+		} else {
+			view.MinNextBid = &nextBid
+		}
+	}
+	return view
+}
+
+// NewViews creates the views of a list of auctions
+func NewViews(auctions []*Auction, buyNowThreshold float64) []*View {
+	views := make([]*View, 0, len(auctions))
+	for _, a := range auctions {
+		views = append(views, NewView(a, buyNowThreshold))
+	}
+	return views
+}
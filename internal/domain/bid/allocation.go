@@ -0,0 +1,61 @@
+package bid
+
+import (
+	"sort"
+
+	"troffee-auction-service/internal/domain/shared"
+)
+
+// Allocation is the number of units a bid wins in a multi-unit auction
+type Allocation struct {
+	Bid      *Bid
+	Quantity int
+}
+
+// AllocateUnits hands out quantity units to the highest bids first, the earliest one
+// on ties. The last bid served may be filled only partially.
+func AllocateUnits(bids []*Bid, quantity int) []Allocation {
+	ranked := make([]*Bid, 0, len(bids))
+	for _, b := range bids {
+		if b.IsAccepted() {
+			ranked = append(ranked, b)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if !ranked[i].Amount.Equal(ranked[j].Amount) {
+			return ranked[i].Amount.GreaterThan(ranked[j].Amount)
+		}
+		return ranked[i].CreatedAt.Before(ranked[j].CreatedAt)
+	})
+
+	var allocations []Allocation
+	remaining := quantity
+	for _, b := range ranked {
+		if remaining == 0 {
+			break
+		}
+
+		units := b.Quantity
+		if units > remaining {
+			units = remaining
+		}
+		allocations = append(allocations, Allocation{Bid: b, Quantity: units})
+		remaining -= units
+	}
+
+	return allocations
+}
+
+// EntryPrice returns the price a new bid has to beat to win units: the lowest
+// allocated bid once every unit is taken, the starting price before that
+func EntryPrice(allocations []Allocation, quantity int, startingPrice shared.Money) shared.Money {
+	allocated := 0
+	for _, allocation := range allocations {
+		allocated += allocation.Quantity
+	}
+
+	if allocated < quantity || len(allocations) == 0 {
+		return startingPrice
+	}
+	return allocations[len(allocations)-1].Bid.Amount
+}
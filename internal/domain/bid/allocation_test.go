@@ -0,0 +1,72 @@
+package bid
+
+import (
+	"testing"
+
+	"troffee-auction-service/internal/domain/shared"
+)
+
+func withQuantity(b *Bid, quantity int) *Bid {
+	b.Quantity = quantity
+	return b
+}
+
+func TestAllocateUnits(t *testing.T) {
+	early := withQuantity(testBid(alice, 10000, 1), 2)
+	late := withQuantity(testBid(bob, 10000, 2), 3)
+	high := withQuantity(testBid(carol, 12000, 3), 1)
+	retracted := withQuantity(withStatus(testBid(carol, 90000, 4), StatusRetracted), 5)
+
+	tests := []struct {
+		name     string
+		bids     []*Bid
+		quantity int
+		want     []Allocation
+	}{
+		{name: "no bids", bids: nil, quantity: 3, want: nil},
+		{name: "every bid filled", bids: []*Bid{early, high}, quantity: 5, want: []Allocation{{high, 1}, {early, 2}}},
+		{name: "last bid filled partially", bids: []*Bid{late, early, high}, quantity: 4, want: []Allocation{{high, 1}, {early, 2}, {late, 1}}},
+		{name: "earliest bid first on ties", bids: []*Bid{late, early}, quantity: 2, want: []Allocation{{early, 2}}},
+		{name: "only accepted bids", bids: []*Bid{retracted, early}, quantity: 3, want: []Allocation{{early, 2}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AllocateUnits(tt.bids, tt.quantity)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d allocations, want %d", len(got), len(tt.want))
+			}
+			for i := range tt.want {
+				if got[i].Bid != tt.want[i].Bid || got[i].Quantity != tt.want[i].Quantity {
+					t.Errorf("allocation %d = %d units at %s, want %d units at %s",
+						i, got[i].Quantity, got[i].Bid.Amount, tt.want[i].Quantity, tt.want[i].Bid.Amount)
+				}
+			}
+		})
+	}
+}
+
+func TestEntryPrice(t *testing.T) {
+	starting := usd(5000)
+	high := withQuantity(testBid(alice, 12000, 1), 2)
+	low := withQuantity(testBid(bob, 10000, 2), 1)
+
+	tests := []struct {
+		name        string
+		allocations []Allocation
+		quantity    int
+		want        shared.Money
+	}{
+		{name: "no allocations", allocations: nil, quantity: 3, want: starting},
+		{name: "units left", allocations: []Allocation{{high, 2}}, quantity: 3, want: starting},
+		{name: "every unit taken", allocations: []Allocation{{high, 2}, {low, 1}}, quantity: 3, want: usd(10000)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EntryPrice(tt.allocations, tt.quantity, starting); !got.Equal(tt.want) {
+				t.Errorf("EntryPrice = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package bid
 
 import (
+	"sort"
 	"time"
 
+	"troffee-auction-service/internal/domain/shared"
+
 	"github.com/google/uuid"
 )
 
@@ -12,22 +15,26 @@ type Status string
 const (
 	StatusAccepted Status = "accepted"
 	StatusRejected Status = "rejected"
+	// StatusRetracted marks a bid withdrawn by its bidder or an admin after it was accepted
+	StatusRetracted Status = "retracted"
 )
 
-// Bid represents a bid on an auction
+// Bid represents a bid on an auction.
+// Amount is the price per unit; Quantity is 1 outside multi-unit auctions.
 type Bid struct {
-	ID        uuid.UUID `json:"id"`
-	AuctionID uuid.UUID `json:"auction_id"`
-	UserID    uuid.UUID `json:"user_id"`
-	Amount    float64   `json:"amount"`
-	Status    Status    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        uuid.UUID    `json:"id"`
+	AuctionID uuid.UUID    `json:"auction_id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	Amount    shared.Money `json:"amount"`
+	Quantity  int          `json:"quantity"`
+	Status    Status       `json:"status"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
 }
 
 // IsValid returns true if the bid amount is valid (greater than 0)
 func (b *Bid) IsValid() bool {
-	return b.Amount > 0
+	return b.Amount.IsPositive()
 }
 
 // Accept marks the bid as accepted
@@ -51,3 +58,49 @@ func (b *Bid) IsAccepted() bool {
 func (b *Bid) IsRejected() bool {
 	return b.Status == StatusRejected
 }
+
+// WithinRetractionWindow returns true if the bid was placed no longer than window before now
+func (b *Bid) WithinRetractionWindow(window time.Duration, now time.Time) bool {
+	return now.Sub(b.CreatedAt) <= window
+}
+
+// RankSealedBids keeps each bidder's best bid and orders them highest first,
+// the earliest bid winning ties
+func RankSealedBids(bids []*Bid) []*Bid {
+	return RankBids(bids, false)
+}
+
+// RankBids keeps each bidder's best accepted bid and orders them best first:
+// lowest first when lowestWins is set, highest first otherwise. The earliest bid wins ties.
+func RankBids(bids []*Bid, lowestWins bool) []*Bid {
+	beats := func(a, b *Bid) bool {
+		if !a.Amount.Equal(b.Amount) {
+			if lowestWins {
+				return a.Amount.LessThan(b.Amount)
+			}
+			return a.Amount.GreaterThan(b.Amount)
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+
+	best := make(map[uuid.UUID]*Bid)
+	for _, b := range bids {
+		if !b.IsAccepted() {
+			continue
+		}
+		current, ok := best[b.UserID]
+		if !ok || beats(b, current) {
+			best[b.UserID] = b
+		}
+	}
+
+	ranked := make([]*Bid, 0, len(best))
+	for _, b := range best {
+		ranked = append(ranked, b)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		return beats(ranked[i], ranked[j])
+	})
+
+	return ranked
+}
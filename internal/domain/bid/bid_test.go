@@ -0,0 +1,103 @@
+package bid
+
+import (
+	"testing"
+	"time"
+
+	"troffee-auction-service/internal/domain/shared"
+
+	"github.com/google/uuid"
+)
+
+var (
+	alice = uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	bob   = uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	carol = uuid.MustParse("00000000-0000-0000-0000-00000000000c")
+
+	epoch = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+)
+
+func usd(minor int64) shared.Money {
+	return shared.NewMoney(minor, "USD")
+}
+
+// testBid is an accepted single-unit bid placed the given number of seconds after epoch
+func testBid(userID uuid.UUID, amount int64, second int) *Bid {
+	return &Bid{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Amount:    usd(amount),
+		Quantity:  1,
+		Status:    StatusAccepted,
+		CreatedAt: epoch.Add(time.Duration(second) * time.Second),
+	}
+}
+
+func withStatus(b *Bid, status Status) *Bid {
+	b.Status = status
+	return b
+}
+
+func TestRankBids(t *testing.T) {
+	aliceLow := testBid(alice, 10000, 1)
+	aliceHigh := testBid(alice, 12000, 2)
+	bobHigh := testBid(bob, 12000, 3)
+	bobRetracted := withStatus(testBid(bob, 20000, 4), StatusRetracted)
+	carolMid := testBid(carol, 11000, 5)
+
+	tests := []struct {
+		name       string
+		bids       []*Bid
+		lowestWins bool
+		want       []*Bid
+	}{
+		{name: "no bids", bids: nil, want: []*Bid{}},
+		{name: "highest first", bids: []*Bid{carolMid, aliceHigh}, want: []*Bid{aliceHigh, carolMid}},
+		{name: "lowest first", bids: []*Bid{carolMid, aliceHigh}, lowestWins: true, want: []*Bid{carolMid, aliceHigh}},
+		{name: "earliest wins ties", bids: []*Bid{bobHigh, aliceHigh}, want: []*Bid{aliceHigh, bobHigh}},
+		{name: "best bid per bidder", bids: []*Bid{aliceLow, aliceHigh, carolMid}, want: []*Bid{aliceHigh, carolMid}},
+		{name: "lowest bid per bidder", bids: []*Bid{aliceLow, aliceHigh, carolMid}, lowestWins: true, want: []*Bid{aliceLow, carolMid}},
+		{name: "only accepted bids", bids: []*Bid{bobRetracted, carolMid}, want: []*Bid{carolMid}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertBids(t, RankBids(tt.bids, tt.lowestWins), tt.want)
+		})
+	}
+}
+
+func TestRankSealedBids(t *testing.T) {
+	first := testBid(alice, 15000, 1)
+	second := testBid(bob, 15000, 2)
+	raised := testBid(bob, 16000, 3)
+	rejected := withStatus(testBid(carol, 50000, 4), StatusRejected)
+
+	tests := []struct {
+		name string
+		bids []*Bid
+		want []*Bid
+	}{
+		{name: "earliest wins ties", bids: []*Bid{second, first}, want: []*Bid{first, second}},
+		{name: "raised bid counts", bids: []*Bid{second, first, raised}, want: []*Bid{raised, first}},
+		{name: "rejected bids are ignored", bids: []*Bid{rejected, first}, want: []*Bid{first}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertBids(t, RankSealedBids(tt.bids), tt.want)
+		})
+	}
+}
+
+func assertBids(t *testing.T, got, want []*Bid) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d bids, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("bid %d = %s by %s, want %s by %s", i, got[i].Amount, got[i].UserID, want[i].Amount, want[i].UserID)
+		}
+	}
+}
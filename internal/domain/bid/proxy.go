@@ -0,0 +1,87 @@
+package bid
+
+import (
+	"sort"
+	"time"
+
+	"troffee-auction-service/internal/domain/shared"
+
+	"github.com/google/uuid"
+)
+
+// ProxyBid represents a user's hidden maximum bid on an auction.
+// The system bids on the user's behalf, never exceeding MaxAmount.
+type ProxyBid struct {
+	ID        uuid.UUID    `json:"id"`
+	AuctionID uuid.UUID    `json:"auction_id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	MaxAmount shared.Money `json:"max_amount"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+/*
+ResolveProxyBids computes the visible bids produced by competing proxies.
+ 1. The proxy with the highest maximum wins (earliest one on ties)
+ 2. The runner-up proxy bids up to its own maximum if it can still beat the current price
+ 3. The winner bids one increment above the strongest competing amount, capped at its maximum;
+    incrementAt gives the auction's increment at a price
+ 4. Nothing is bid if the winner already leads and nobody challenges it
+
+The returned bids are in ascending amount order and are already accepted.
+*/
+func ResolveProxyBids(proxies []*ProxyBid, currentPrice shared.Money, leaderID *uuid.UUID, incrementAt func(price shared.Money) shared.Money, now time.Time) []*Bid {
+	if len(proxies) == 0 {
+		return nil
+	}
+
+	ranked := make([]*ProxyBid, len(proxies))
+	copy(ranked, proxies)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if !ranked[i].MaxAmount.Equal(ranked[j].MaxAmount) {
+			return ranked[i].MaxAmount.GreaterThan(ranked[j].MaxAmount)
+		}
+		return ranked[i].UpdatedAt.Before(ranked[j].UpdatedAt)
+	})
+
+	top := ranked[0]
+	topLeads := leaderID != nil && *leaderID == top.UserID
+	if !topLeads && !top.MaxAmount.GreaterThan(currentPrice) {
+		return nil
+	}
+
+	// The strongest amount the winning proxy has to beat
+	opposition := currentPrice
+	var runnerUp *ProxyBid
+	if len(ranked) > 1 && ranked[1].UserID != top.UserID && ranked[1].MaxAmount.GreaterThan(currentPrice) {
+		runnerUp = ranked[1]
+		opposition = runnerUp.MaxAmount
+	}
+
+	if topLeads && runnerUp == nil {
+		return nil
+	}
+
+	var bids []*Bid
+	if runnerUp != nil && runnerUp.MaxAmount.LessThan(top.MaxAmount) {
+		bids = append(bids, newProxyBid(runnerUp, runnerUp.MaxAmount, now))
+	}
+
+	price := shared.MinMoney(opposition.Add(incrementAt(opposition)), top.MaxAmount)
+	bids = append(bids, newProxyBid(top, price, now))
+
+	return bids
+}
+
+func newProxyBid(proxy *ProxyBid, amount shared.Money, now time.Time) *Bid {
+	return &Bid{
+		ID:        uuid.New(),
+		AuctionID: proxy.AuctionID,
+		UserID:    proxy.UserID,
+		Amount:    amount,
+		Quantity:  1,
+		Status:    StatusAccepted,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
@@ -0,0 +1,89 @@
+package bid
+
+import (
+	"testing"
+	"time"
+
+	"troffee-auction-service/internal/domain/shared"
+
+	"github.com/google/uuid"
+)
+
+func testProxy(userID uuid.UUID, maxAmount int64, second int) *ProxyBid {
+	return &ProxyBid{
+		ID:        uuid.New(),
+		UserID:    userID,
+		MaxAmount: usd(maxAmount),
+		UpdatedAt: epoch.Add(time.Duration(second) * time.Second),
+	}
+}
+
+func TestResolveProxyBids(t *testing.T) {
+	oneDollar := func(shared.Money) shared.Money { return usd(100) }
+
+	type proxyBid struct {
+		userID uuid.UUID
+		amount int64
+	}
+
+	tests := []struct {
+		name     string
+		proxies  []*ProxyBid
+		current  int64
+		leaderID *uuid.UUID
+		want     []proxyBid
+	}{
+		{name: "no proxies", proxies: nil, current: 10000, want: nil},
+		{name: "single proxy outbids", proxies: []*ProxyBid{testProxy(alice, 15000, 1)}, current: 10000, want: []proxyBid{{alice, 10100}}},
+		{name: "single proxy already leads", proxies: []*ProxyBid{testProxy(alice, 15000, 1)}, current: 10000, leaderID: &alice, want: nil},
+		{name: "maximum not above price", proxies: []*ProxyBid{testProxy(alice, 10000, 1)}, current: 10000, want: nil},
+		{
+			name:    "runner-up bids its maximum",
+			proxies: []*ProxyBid{testProxy(bob, 12000, 1), testProxy(alice, 15000, 2)},
+			current: 10000,
+			want:    []proxyBid{{bob, 12000}, {alice, 12100}},
+		},
+		{
+			name:    "winner capped at its maximum",
+			proxies: []*ProxyBid{testProxy(alice, 15000, 1), testProxy(bob, 14950, 2)},
+			current: 10000,
+			want:    []proxyBid{{bob, 14950}, {alice, 15000}},
+		},
+		{
+			name:    "earliest proxy wins ties",
+			proxies: []*ProxyBid{testProxy(bob, 15000, 2), testProxy(alice, 15000, 1)},
+			current: 10000,
+			want:    []proxyBid{{alice, 15000}},
+		},
+		{
+			name:    "runner-up below price",
+			proxies: []*ProxyBid{testProxy(alice, 15000, 1), testProxy(bob, 9000, 2)},
+			current: 10000,
+			want:    []proxyBid{{alice, 10100}},
+		},
+		{
+			name:     "leader challenged",
+			proxies:  []*ProxyBid{testProxy(alice, 15000, 1), testProxy(bob, 12000, 2)},
+			current:  10000,
+			leaderID: &alice,
+			want:     []proxyBid{{bob, 12000}, {alice, 12100}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ResolveProxyBids(tt.proxies, usd(tt.current), tt.leaderID, oneDollar, epoch)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d bids, want %d", len(got), len(tt.want))
+			}
+			for i, want := range tt.want {
+				if got[i].UserID != want.userID || got[i].Amount.Minor() != want.amount {
+					t.Errorf("bid %d = %s by %s, want %s by %s", i, got[i].Amount, got[i].UserID, usd(want.amount), want.userID)
+				}
+				if !got[i].IsAccepted() || !got[i].CreatedAt.Equal(epoch) {
+					t.Errorf("bid %d = %s at %s, want accepted at %s", i, got[i].Status, got[i].CreatedAt, epoch)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package bid
+
+import (
+	"time"
+
+	"troffee-auction-service/internal/domain/shared"
+
+	"github.com/google/uuid"
+)
+
+// Retraction is the audit record of a withdrawn bid: who withdrew it, why,
+// and how the auction price moved as a result
+type Retraction struct {
+	ID            uuid.UUID    `json:"id"`
+	BidID         uuid.UUID    `json:"bid_id"`
+	AuctionID     uuid.UUID    `json:"auction_id"`
+	RetractedBy   uuid.UUID    `json:"retracted_by"`
+	Reason        string       `json:"reason"`
+	PreviousPrice shared.Money `json:"previous_price"`
+	NewPrice      shared.Money `json:"new_price"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
@@ -0,0 +1,105 @@
+package ledger
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"troffee-auction-service/internal/domain/shared"
+)
+
+// FeeTier charges Percent on the part of an amount that falls in the tier.
+// UpTo is the upper bound of the tier; zero means unbounded.
+type FeeTier struct {
+	Percent float64      `json:"percent"`
+	UpTo    shared.Money `json:"up_to"`
+}
+
+// FeeSchedule is a marginal fee schedule, like a tax bracket: every tier charges its
+// percentage only on the part of the amount between the previous bound and its own.
+// An empty schedule charges nothing.
+type FeeSchedule []FeeTier
+
+/*
+ParseFeeSchedule reads a schedule written as comma separated PERCENT[:UP_TO] tiers in
+ascending order, e.g. "25:1000,20" charges 25% on the first 1000 and 20% on the rest.
+Only the last tier may be unbounded.
+*/
+func ParseFeeSchedule(value string) (FeeSchedule, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	var schedule FeeSchedule
+	for _, part := range strings.Split(value, ",") {
+		percentStr, upToStr, bounded := strings.Cut(strings.TrimSpace(part), ":")
+
+		percent, err := strconv.ParseFloat(percentStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fee percent %q: %w", percentStr, err)
+		}
+
+		tier := FeeTier{Percent: percent}
+		if bounded {
+			if tier.UpTo, err = shared.ParseMoney(upToStr, shared.DefaultCurrency); err != nil {
+				return nil, fmt.Errorf("invalid fee tier bound %q: %w", upToStr, err)
+			}
+		}
+
+		schedule = append(schedule, tier)
+	}
+
+	if !schedule.Valid() {
+		return nil, fmt.Errorf("invalid fee schedule %q", value)
+	}
+
+	return schedule, nil
+}
+
+// Valid returns true if the percentages are between 0 and 100 and the bounds ascend,
+// with only the last tier left unbounded
+func (s FeeSchedule) Valid() bool {
+	var previous shared.Money
+	for i, tier := range s {
+		if tier.Percent < 0 || tier.Percent > 100 {
+			return false
+		}
+
+		last := i == len(s)-1
+		if tier.UpTo.IsZero() {
+			if !last {
+				return false
+			}
+			continue
+		}
+		if !tier.UpTo.GreaterThan(previous) {
+			return false
+		}
+		previous = tier.UpTo
+	}
+
+	return true
+}
+
+// FeeFor returns the fee charged on an amount, rounded once to the nearest cent
+func (s FeeSchedule) FeeFor(amount shared.Money) shared.Money {
+	fee := 0.0
+	var lower shared.Money
+	for _, tier := range s {
+		upper := amount
+		if tier.UpTo.IsPositive() && tier.UpTo.LessThan(amount) {
+			upper = tier.UpTo
+		}
+		if upper.GreaterThan(lower) {
+			fee += float64(upper.Sub(lower).Minor()) * tier.Percent / 100
+		}
+		if tier.UpTo.IsZero() || !tier.UpTo.LessThan(amount) {
+			break
+		}
+		lower = tier.UpTo
+	}
+
+	return shared.NewMoney(int64(math.Round(fee)), amount.Currency())
+}
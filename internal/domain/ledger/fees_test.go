@@ -0,0 +1,86 @@
+package ledger
+
+import (
+	"testing"
+
+	"troffee-auction-service/internal/domain/shared"
+)
+
+func usd(minor int64) shared.Money {
+	return shared.NewMoney(minor, "USD")
+}
+
+func TestParseFeeSchedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    FeeSchedule
+		wantErr bool
+	}{
+		{name: "empty", value: "", want: nil},
+		{name: "blank", value: "  ", want: nil},
+		{name: "flat", value: "5", want: FeeSchedule{{Percent: 5}}},
+		{name: "tiered", value: "25:1000,20", want: FeeSchedule{{Percent: 25, UpTo: usd(100000)}, {Percent: 20}}},
+		{name: "bounded last tier", value: "10:100, 5:250.50", want: FeeSchedule{{Percent: 10, UpTo: usd(10000)}, {Percent: 5, UpTo: usd(25050)}}},
+		{name: "bad percent", value: "abc", wantErr: true},
+		{name: "bad bound", value: "10:x", wantErr: true},
+		{name: "unbounded tier not last", value: "20,10:100", wantErr: true},
+		{name: "bounds not ascending", value: "10:100,5:50", wantErr: true},
+		{name: "percent above 100", value: "101", wantErr: true},
+		{name: "negative percent", value: "-1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFeeSchedule(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseFeeSchedule(%q) = %v, want an error", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseFeeSchedule(%q) error = %v", tt.value, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseFeeSchedule(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i].Percent != tt.want[i].Percent || !got[i].UpTo.Equal(tt.want[i].UpTo) {
+					t.Errorf("tier %d = %v%% up to %s, want %v%% up to %s", i, got[i].Percent, got[i].UpTo, tt.want[i].Percent, tt.want[i].UpTo)
+				}
+			}
+		})
+	}
+}
+
+func TestFeeScheduleFeeFor(t *testing.T) {
+	tiered := FeeSchedule{{Percent: 25, UpTo: usd(100000)}, {Percent: 20}}
+	bounded := FeeSchedule{{Percent: 10, UpTo: usd(10000)}}
+
+	tests := []struct {
+		name     string
+		schedule FeeSchedule
+		amount   shared.Money
+		want     shared.Money
+	}{
+		{name: "empty schedule", schedule: nil, amount: usd(100000), want: usd(0)},
+		{name: "nothing sold", schedule: tiered, amount: usd(0), want: usd(0)},
+		{name: "first tier", schedule: tiered, amount: usd(50000), want: usd(12500)},
+		{name: "at the bound", schedule: tiered, amount: usd(100000), want: usd(25000)},
+		{name: "across tiers", schedule: tiered, amount: usd(150000), want: usd(35000)},
+		{name: "rounded down", schedule: tiered, amount: usd(5), want: usd(1)},
+		{name: "rounded up", schedule: tiered, amount: usd(6), want: usd(2)},
+		{name: "nothing above a bounded last tier", schedule: bounded, amount: usd(20000), want: usd(1000)},
+		{name: "in the amount's currency", schedule: tiered, amount: shared.NewMoney(50000, "EUR"), want: shared.NewMoney(12500, "EUR")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.schedule.FeeFor(tt.amount)
+			if !got.Equal(tt.want) || !got.SameCurrency(tt.want) {
+				t.Errorf("FeeFor(%s) = %s %s, want %s %s", tt.amount, got, got.Currency(), tt.want, tt.want.Currency())
+			}
+		})
+	}
+}
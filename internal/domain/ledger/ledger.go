@@ -0,0 +1,161 @@
+package ledger
+
+import (
+	"time"
+
+	"troffee-auction-service/internal/domain/shared"
+
+	"github.com/google/uuid"
+)
+
+// AccountType identifies who an account's balance belongs to
+type AccountType string
+
+const (
+	// AccountTypeUser holds what the marketplace owes a user (positive) or is owed by them (negative)
+	AccountTypeUser AccountType = "user"
+	// AccountTypePlatformFees collects buyer premiums and seller fees
+	AccountTypePlatformFees AccountType = "platform_fees"
+	// AccountTypePaymentClearing mirrors the money collected through the payment gateway
+	AccountTypePaymentClearing AccountType = "payment_clearing"
+)
+
+// Account is a ledger account; UserID is only set for user accounts
+type Account struct {
+	Type   AccountType `json:"type"`
+	UserID *uuid.UUID  `json:"user_id,omitempty"`
+}
+
+// UserAccount returns the account of a user
+func UserAccount(userID uuid.UUID) Account {
+	return Account{Type: AccountTypeUser, UserID: &userID}
+}
+
+// EntryKind describes why an entry was posted
+type EntryKind string
+
+const (
+	EntryKindPayment      EntryKind = "payment"
+	EntryKindPurchase     EntryKind = "purchase"
+	EntryKindSaleProceeds EntryKind = "sale_proceeds"
+	EntryKindBuyerPremium EntryKind = "buyer_premium"
+	EntryKindSellerFee    EntryKind = "seller_fee"
+)
+
+// TransactionKind describes the business event a transaction records
+type TransactionKind string
+
+const (
+	TransactionKindOrderSettlement TransactionKind = "order_settlement"
+)
+
+// Entry is one side of a transaction. Positive amounts credit the account, negative amounts debit it.
+type Entry struct {
+	ID            uuid.UUID       `json:"id"`
+	TransactionID uuid.UUID       `json:"transaction_id"`
+	Account       Account         `json:"account"`
+	Kind          EntryKind       `json:"kind"`
+	Amount        shared.Money    `json:"amount"`
+	Currency      shared.Currency `json:"currency"`
+	OrderID       *uuid.UUID      `json:"order_id,omitempty"`
+	AuctionID     *uuid.UUID      `json:"auction_id,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// Transaction is a set of entries that always sum to zero
+type Transaction struct {
+	ID        uuid.UUID       `json:"id"`
+	Kind      TransactionKind `json:"kind"`
+	OrderID   *uuid.UUID      `json:"order_id,omitempty"`
+	Entries   []*Entry        `json:"entries"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// Balanced returns true if the entries of the transaction share a currency and sum to zero
+func (t *Transaction) Balanced() bool {
+	var sum shared.Money
+	for _, entry := range t.Entries {
+		if entry.Currency != t.Entries[0].Currency {
+			return false
+		}
+		sum = sum.Add(entry.Amount)
+	}
+	return sum.IsZero()
+}
+
+// Balance is the sum of the entries posted to a user's account in one currency
+type Balance struct {
+	UserID   uuid.UUID       `json:"user_id"`
+	Amount   shared.Money    `json:"amount"`
+	Currency shared.Currency `json:"currency"`
+}
+
+// Settlement is the money breakdown of a paid order, all amounts in the order's currency
+type Settlement struct {
+	OrderID      uuid.UUID
+	AuctionID    uuid.UUID
+	BuyerID      uuid.UUID
+	SellerID     uuid.UUID
+	HammerPrice  shared.Money
+	BuyerPremium shared.Money
+	SellerFee    shared.Money
+}
+
+// BuyerTotal is what the buyer pays: the hammer price plus the buyer's premium
+func (s Settlement) BuyerTotal() shared.Money {
+	return s.HammerPrice.Add(s.BuyerPremium)
+}
+
+// SellerPayout is what the seller receives: the hammer price minus the seller fee
+func (s Settlement) SellerPayout() shared.Money {
+	return s.HammerPrice.Sub(s.SellerFee)
+}
+
+/*
+NewSettlementTransaction records a paid order:
+ 1. The payment collected by the gateway is credited to the buyer and mirrored in payment clearing
+ 2. The purchase is debited from the buyer
+ 3. The seller is credited the hammer price minus the seller fee
+ 4. The buyer's premium and the seller fee are credited to platform fees
+*/
+func NewSettlementTransaction(s Settlement, now time.Time) *Transaction {
+	tx := &Transaction{
+		ID:        uuid.New(),
+		Kind:      TransactionKindOrderSettlement,
+		OrderID:   &s.OrderID,
+		CreatedAt: now,
+	}
+
+	platformFees := Account{Type: AccountTypePlatformFees}
+	entries := []struct {
+		account Account
+		kind    EntryKind
+		amount  shared.Money
+	}{
+		{Account{Type: AccountTypePaymentClearing}, EntryKindPayment, s.BuyerTotal().Neg()},
+		{UserAccount(s.BuyerID), EntryKindPayment, s.BuyerTotal()},
+		{UserAccount(s.BuyerID), EntryKindPurchase, s.BuyerTotal().Neg()},
+		{UserAccount(s.SellerID), EntryKindSaleProceeds, s.SellerPayout()},
+		{platformFees, EntryKindBuyerPremium, s.BuyerPremium},
+		{platformFees, EntryKindSellerFee, s.SellerFee},
+	}
+
+	for _, e := range entries {
+		if e.amount.IsZero() {
+			continue
+		}
+		tx.Entries = append(tx.Entries, &Entry{
+			ID:            uuid.New(),
+			TransactionID: tx.ID,
+			Account:       e.account,
+			Kind:          e.kind,
+			Amount:        e.amount,
+			Currency:      e.amount.Currency(),
+			OrderID:       &s.OrderID,
+			AuctionID:     &s.AuctionID,
+			CreatedAt:     now,
+		})
+	}
+
+	return tx
+}
@@ -0,0 +1,125 @@
+package ledger
+
+import (
+	"testing"
+	"time"
+
+	"troffee-auction-service/internal/domain/shared"
+
+	"github.com/google/uuid"
+)
+
+func TestNewSettlementTransaction(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	buyerID := uuid.New()
+	sellerID := uuid.New()
+
+	tests := []struct {
+		name        string
+		settlement  Settlement
+		wantEntries int
+		wantBuyer   int64
+		wantSeller  int64
+		wantFees    int64
+		wantCleared int64
+	}{
+		{
+			name:        "premium and seller fee",
+			settlement:  Settlement{HammerPrice: usd(10000), BuyerPremium: usd(1500), SellerFee: usd(1000)},
+			wantEntries: 6,
+			wantSeller:  9000,
+			wantFees:    2500,
+			wantCleared: -11500,
+		},
+		{
+			name:        "no fees",
+			settlement:  Settlement{HammerPrice: usd(10000)},
+			wantEntries: 4,
+			wantSeller:  10000,
+			wantCleared: -10000,
+		},
+		{
+			name: "other currency",
+			settlement: Settlement{
+				HammerPrice:  shared.NewMoney(2500, "EUR"),
+				BuyerPremium: shared.NewMoney(313, "EUR"),
+				SellerFee:    shared.NewMoney(125, "EUR"),
+			},
+			wantEntries: 6,
+			wantSeller:  2375,
+			wantFees:    438,
+			wantCleared: -2813,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.settlement
+			s.OrderID = uuid.New()
+			s.AuctionID = uuid.New()
+			s.BuyerID = buyerID
+			s.SellerID = sellerID
+
+			tx := NewSettlementTransaction(s, now)
+
+			if !tx.Balanced() {
+				t.Fatal("settlement transaction is not balanced")
+			}
+			if tx.Kind != TransactionKindOrderSettlement || tx.OrderID == nil || *tx.OrderID != s.OrderID {
+				t.Errorf("transaction %s for order %v, want %s for %s", tx.Kind, tx.OrderID, TransactionKindOrderSettlement, s.OrderID)
+			}
+			if len(tx.Entries) != tt.wantEntries {
+				t.Fatalf("got %d entries, want %d", len(tx.Entries), tt.wantEntries)
+			}
+
+			var buyer, seller, fees, cleared int64
+			for _, entry := range tx.Entries {
+				if entry.TransactionID != tx.ID || !entry.CreatedAt.Equal(now) || entry.Currency != s.HammerPrice.Currency() {
+					t.Errorf("entry %s = transaction %s at %s in %s, want %s at %s in %s",
+						entry.Kind, entry.TransactionID, entry.CreatedAt, entry.Currency, tx.ID, now, s.HammerPrice.Currency())
+				}
+				switch {
+				case entry.Account.Type == AccountTypeUser && *entry.Account.UserID == buyerID:
+					buyer += entry.Amount.Minor()
+				case entry.Account.Type == AccountTypeUser && *entry.Account.UserID == sellerID:
+					seller += entry.Amount.Minor()
+				case entry.Account.Type == AccountTypePlatformFees:
+					fees += entry.Amount.Minor()
+				case entry.Account.Type == AccountTypePaymentClearing:
+					cleared += entry.Amount.Minor()
+				}
+			}
+
+			if buyer != tt.wantBuyer || seller != tt.wantSeller || fees != tt.wantFees || cleared != tt.wantCleared {
+				t.Errorf("buyer %d seller %d fees %d clearing %d, want %d %d %d %d",
+					buyer, seller, fees, cleared, tt.wantBuyer, tt.wantSeller, tt.wantFees, tt.wantCleared)
+			}
+		})
+	}
+}
+
+func TestTransactionBalanced(t *testing.T) {
+	entry := func(amount shared.Money) *Entry {
+		return &Entry{Amount: amount, Currency: amount.Currency()}
+	}
+
+	tests := []struct {
+		name    string
+		entries []*Entry
+		want    bool
+	}{
+		{name: "no entries", entries: nil, want: true},
+		{name: "sums to zero", entries: []*Entry{entry(usd(500)), entry(usd(-300)), entry(usd(-200))}, want: true},
+		{name: "off by a cent", entries: []*Entry{entry(usd(500)), entry(usd(-499))}, want: false},
+		{name: "mixed currencies", entries: []*Entry{entry(usd(500)), entry(shared.NewMoney(-500, "EUR"))}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &Transaction{Entries: tt.entries}
+			if got := tx.Balanced(); got != tt.want {
+				t.Errorf("Balanced = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package order
+
+import (
+	"time"
+
+	"troffee-auction-service/internal/domain/shared"
+
+	"github.com/google/uuid"
+)
+
+// Status represents where an order is in settlement
+type Status string
+
+const (
+	StatusAwaitingPayment Status = "awaiting_payment"
+	// StatusProcessing means a payment attempt is in flight; the order neither expires nor takes
+	// another attempt until the gateway answered
+	StatusProcessing Status = "processing"
+	StatusPaid       Status = "paid"
+	// StatusFailed means the last payment attempt failed; the buyer may retry until the order is due
+	StatusFailed  Status = "failed"
+	StatusExpired Status = "expired"
+)
+
+// Order is what a winner owes for the units won in an auction. Amount is what the buyer pays,
+// the hammer price plus the buyer's premium; SellerPayout is the hammer price minus the seller fee.
+type Order struct {
+	ID               uuid.UUID       `json:"id"`
+	AuctionID        uuid.UUID       `json:"auction_id"`
+	BidID            uuid.UUID       `json:"bid_id"`
+	BuyerID          uuid.UUID       `json:"buyer_id"`
+	SellerID         uuid.UUID       `json:"seller_id"`
+	Quantity         int             `json:"quantity"`
+	UnitPrice        shared.Money    `json:"unit_price"`
+	HammerPrice      shared.Money    `json:"hammer_price"`
+	BuyerPremium     shared.Money    `json:"buyer_premium"`
+	SellerFee        shared.Money    `json:"seller_fee"`
+	SellerPayout     shared.Money    `json:"seller_payout"`
+	Amount           shared.Money    `json:"amount"`
+	Currency         shared.Currency `json:"currency"`
+	Status           Status          `json:"status"`
+	PaymentReference string          `json:"payment_reference,omitempty"`
+	FailureReason    string          `json:"failure_reason,omitempty"`
+	DueAt            time.Time       `json:"due_at"`
+	PaidAt           *time.Time      `json:"paid_at,omitempty"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+}
+
+// New creates an order awaiting payment of quantity units at unitPrice, due after paymentWindow.
+// The order is in the currency of unitPrice.
+func New(auctionID, bidID, buyerID, sellerID uuid.UUID, quantity int, unitPrice shared.Money, paymentWindow time.Duration, now time.Time) *Order {
+	hammerPrice := unitPrice.Mul(quantity)
+
+	return &Order{
+		ID:           uuid.New(),
+		AuctionID:    auctionID,
+		BidID:        bidID,
+		BuyerID:      buyerID,
+		SellerID:     sellerID,
+		Quantity:     quantity,
+		UnitPrice:    unitPrice,
+		HammerPrice:  hammerPrice,
+		SellerPayout: hammerPrice,
+		Amount:       hammerPrice,
+		Currency:     unitPrice.Currency(),
+		Status:       StatusAwaitingPayment,
+		DueAt:        now.Add(paymentWindow),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+// ApplyFees charges the buyer's premium on top of the hammer price and takes the seller fee out of the payout
+func (o *Order) ApplyFees(buyerPremium, sellerFee shared.Money) {
+	o.BuyerPremium = buyerPremium
+	o.SellerFee = sellerFee
+	o.Amount = o.HammerPrice.Add(buyerPremium)
+	o.SellerPayout = o.HammerPrice.Sub(sellerFee)
+}
+
+// SetCurrency sets the currency of the order and tags every amount with it
+func (o *Order) SetCurrency(currency shared.Currency) {
+	o.Currency = currency
+	for _, amount := range []*shared.Money{&o.UnitPrice, &o.HammerPrice, &o.BuyerPremium, &o.SellerFee, &o.SellerPayout, &o.Amount} {
+		*amount = amount.WithCurrency(currency)
+	}
+}
+
+// CanBePaid returns true if the order still waits for a successful payment
+func (o *Order) CanBePaid() bool {
+	return o.Status == StatusAwaitingPayment || o.Status == StatusFailed
+}
+
+// IsProcessing returns true if a payment attempt is in flight
+func (o *Order) IsProcessing() bool {
+	return o.Status == StatusProcessing
+}
+
+// StartPayment claims the order for a payment attempt
+func (o *Order) StartPayment() {
+	o.Status = StatusProcessing
+	o.UpdatedAt = time.Now()
+}
+
+// IsPaid returns true if the order was paid
+func (o *Order) IsPaid() bool {
+	return o.Status == StatusPaid
+}
+
+// IsOverdueAt returns true if the order is still unpaid at or after its due time
+func (o *Order) IsOverdueAt(now time.Time) bool {
+	return o.CanBePaid() && !now.Before(o.DueAt)
+}
+
+// IsDefaultedAt returns true if the buyer can no longer pay the order at now: it expired,
+// or is still unpaid past its due time. A failed payment alone may still be retried.
+func (o *Order) IsDefaultedAt(now time.Time) bool {
+	return o.Status == StatusExpired || o.IsOverdueAt(now)
+}
+
+// MarkPaid records a successful payment
+func (o *Order) MarkPaid(reference string, now time.Time) {
+	o.Status = StatusPaid
+	o.PaymentReference = reference
+	o.FailureReason = ""
+	o.PaidAt = &now
+	o.UpdatedAt = now
+}
+
+// FailPayment records a failed payment attempt
+func (o *Order) FailPayment(reason string) {
+	o.Status = StatusFailed
+	o.FailureReason = reason
+	o.UpdatedAt = time.Now()
+}
+
+// Expire closes an order that was not paid in time
+func (o *Order) Expire() {
+	o.Status = StatusExpired
+	o.UpdatedAt = time.Now()
+}
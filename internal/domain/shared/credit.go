@@ -0,0 +1,49 @@
+package shared
+
+// RateFunc returns how many units of to one unit of from buys, or ErrFXRateUnavailable
+type RateFunc func(from, to Currency) (float64, error)
+
+// SumIn adds up amounts held in several currencies as one amount in currency, converting the
+// others at the rates given by rate. A nil rate only sums amounts already in currency.
+func SumIn(amounts []Money, currency Currency, rate RateFunc) (Money, error) {
+	total := Money{currency: currency}
+	for _, amount := range amounts {
+		if amount.Currency() == currency {
+			total = total.Add(amount)
+			continue
+		}
+		if rate == nil {
+			return Money{}, ErrFXRateUnavailable
+		}
+		r, err := rate(amount.Currency(), currency)
+		if err != nil {
+			return Money{}, err
+		}
+		total = total.Add(amount.Convert(r, currency))
+	}
+	return total, nil
+}
+
+// CheckCredit fails with a CreditLimitError if a new commitment of amount would take the user's
+// open exposure, one amount per currency, past their credit limit. Both are converted into the
+// currency of the limit first; a currency without a rate fails with ErrCreditCurrencyUnconvertible.
+func (u *User) CheckCredit(exposure []Money, amount Money, rate RateFunc) error {
+	if u.CreditLimit == nil {
+		return nil
+	}
+
+	currency := u.CreditLimit.Currency()
+	total, err := SumIn(exposure, currency, rate)
+	if err != nil {
+		return ErrCreditCurrencyUnconvertible
+	}
+	committed, err := SumIn([]Money{amount}, currency, rate)
+	if err != nil {
+		return ErrCreditCurrencyUnconvertible
+	}
+
+	if !u.HasCreditFor(total, committed) {
+		return &CreditLimitError{CreditLimit: *u.CreditLimit, Exposure: total}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package shared
+
+import (
+	"errors"
+	"testing"
+)
+
+// testRates prices one EUR at 1.10 USD; other pairs have no rate
+func testRates(from, to Currency) (float64, error) {
+	switch {
+	case from == "EUR" && to == "USD":
+		return 1.10, nil
+	case from == "USD" && to == "EUR":
+		return 1 / 1.10, nil
+	}
+	return 0, ErrFXRateUnavailable
+}
+
+func usd(minor int64) Money { return NewMoney(minor, "USD") }
+func eur(minor int64) Money { return NewMoney(minor, "EUR") }
+
+func TestSumIn(t *testing.T) {
+	tests := []struct {
+		name    string
+		amounts []Money
+		rate    RateFunc
+		want    Money
+		wantErr error
+	}{
+		{name: "nothing", amounts: nil, want: usd(0)},
+		{name: "same currency", amounts: []Money{usd(1000), usd(250)}, want: usd(1250)},
+		{name: "same currency without rates", amounts: []Money{usd(1000)}, rate: nil, want: usd(1000)},
+		{name: "converted", amounts: []Money{usd(1000), eur(1000)}, rate: testRates, want: usd(2100)},
+		{name: "converted rounds to cents", amounts: []Money{eur(5)}, rate: testRates, want: usd(6)},
+		{name: "no rates", amounts: []Money{usd(1000), eur(1000)}, rate: nil, wantErr: ErrFXRateUnavailable},
+		{name: "unknown pair", amounts: []Money{NewMoney(100, "GBP")}, rate: testRates, wantErr: ErrFXRateUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SumIn(tt.amounts, "USD", tt.rate)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("SumIn error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SumIn error = %v", err)
+			}
+			if !got.Equal(tt.want) || got.Currency() != "USD" {
+				t.Errorf("SumIn = %s %s, want %s USD", got, got.Currency(), tt.want)
+			}
+		})
+	}
+}
+
+func TestUserCheckCredit(t *testing.T) {
+	limit := usd(10000)
+
+	tests := []struct {
+		name     string
+		limit    *Money
+		exposure []Money
+		amount   Money
+		wantErr  error
+	}{
+		{name: "no limit", limit: nil, exposure: []Money{usd(1000000)}, amount: usd(1000000)},
+		{name: "within limit", limit: &limit, exposure: []Money{usd(4000)}, amount: usd(5000)},
+		{name: "exactly at limit", limit: &limit, exposure: []Money{usd(4000)}, amount: usd(6000)},
+		{name: "past limit", limit: &limit, exposure: []Money{usd(4000)}, amount: usd(6001), wantErr: ErrCreditLimitExceeded},
+		{name: "converted exposure", limit: &limit, exposure: []Money{eur(5000)}, amount: usd(4500)},
+		{name: "converted exposure past limit", limit: &limit, exposure: []Money{eur(5000)}, amount: usd(4501), wantErr: ErrCreditLimitExceeded},
+		{name: "converted amount past limit", limit: &limit, exposure: []Money{usd(5000)}, amount: eur(5000), wantErr: ErrCreditLimitExceeded},
+		{name: "unconvertible exposure", limit: &limit, exposure: []Money{NewMoney(100, "GBP")}, amount: usd(100), wantErr: ErrCreditCurrencyUnconvertible},
+		{name: "unconvertible amount", limit: &limit, exposure: nil, amount: NewMoney(100, "GBP"), wantErr: ErrCreditCurrencyUnconvertible},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{CreditLimit: tt.limit}
+			err := user.CheckCredit(tt.exposure, tt.amount, testRates)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("CheckCredit error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CheckCredit error = %v, want %v", err, tt.wantErr)
+			}
+
+			var limitErr *CreditLimitError
+			if errors.As(err, &limitErr) && !limitErr.CreditLimit.Equal(limit) {
+				t.Errorf("CreditLimitError.CreditLimit = %s, want %s", limitErr.CreditLimit, limit)
+			}
+		})
+	}
+}
@@ -6,10 +6,29 @@ import (
 	"github.com/google/uuid"
 )
 
+// User roles
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 // User represents an authenticated user in the system
 type User struct {
 	ID   uuid.UUID `json:"id"`
 	Name string    `json:"name"`
+	Role string    `json:"role"`
+	// CreditLimit caps the sum of the user's winning bids on active auctions; nil means no limit
+	CreditLimit *Money `json:"credit_limit,omitempty"`
+}
+
+// IsAdmin returns true if the user may manage any auction
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
+
+// HasCreditFor returns true if a new commitment of amount fits next to the user's open exposure
+func (u *User) HasCreditFor(exposure, amount Money) bool {
+	return u.CreditLimit == nil || !exposure.Add(amount).GreaterThan(*u.CreditLimit)
 }
 
 // Item represents an item that can be auctioned
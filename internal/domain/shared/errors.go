@@ -1,6 +1,9 @@
 package shared
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Domain-specific errors
 var (
@@ -12,16 +15,76 @@ var (
 	ErrInvalidEndTime          = errors.New("end time must be after start time")
 	ErrInvalidStartingPrice    = errors.New("starting price must be greater than 0")
 	ErrItemAlreadyInAuction    = errors.New("item is already in an active auction")
+	ErrInvalidSoftCloseWindow  = errors.New("soft close window cannot be negative")
+	ErrInvalidReservePrice     = errors.New("reserve price must be higher than starting price")
+	ErrInvalidBuyNowPrice      = errors.New("buy now price must be higher than starting and reserve price")
+	ErrBuyNowUnavailable       = errors.New("buy now is not available for this auction")
+	ErrAuctionPriceChanged     = errors.New("auction price changed, please retry")
+	ErrInvalidAuctionType      = errors.New("invalid auction type")
+	ErrInvalidPriceDropRule    = errors.New("dutch auctions need a positive price drop, interval and a floor below the starting price")
+	ErrUnsupportedAuctionType  = errors.New("operation not supported for this auction type")
+	ErrInvalidQuantity         = errors.New("quantity must be at least 1")
+	ErrInvalidPricing          = errors.New("pricing must be pay_as_bid or uniform")
+	ErrInvalidIncrementRule    = errors.New("increment rule must be a positive flat increment or ascending tiers of positive increments")
+	ErrAuctionNotEnded         = errors.New("auction end time has not been reached")
+	ErrAuctionStatusChanged    = errors.New("auction status changed, please retry")
+	ErrAuctionNotCancellable   = errors.New("only pending or active auctions can be cancelled")
+	ErrNotAuctionOwner         = errors.New("only the auction creator or an admin can do this")
+	ErrCancelReasonRequired    = errors.New("a reason is required to cancel an auction that has bids")
+	ErrAuctionNotEditable      = errors.New("only pending or active auctions can be edited")
+	ErrAuctionHasBids          = errors.New("auction cannot be edited once bids were placed")
+	ErrEndTimeInPast           = errors.New("end time cannot be in the past")
+	ErrInvalidRelistPolicy     = errors.New("relist policy needs at least one relist, a positive duration and a price drop below 100%")
+	ErrAuctionNotRelistable    = errors.New("auction cannot be relisted")
+
+	// Second-chance offer errors
+	ErrSecondChanceUnavailable     = errors.New("second-chance offers need an ended single-unit auction with a winner")
+	ErrWinnerNotDefaulted          = errors.New("second-chance offers need the winner's order to be expired or overdue")
+	ErrSecondChanceOfferOpen       = errors.New("a second-chance offer for this auction is still open")
+	ErrSecondChanceAlreadyAccepted = errors.New("the item was already sold through a second-chance offer")
+	ErrNoRunnerUp                  = errors.New("no runner-up bidder left to offer the item to")
+	ErrSecondChanceOfferNotFound   = errors.New("second-chance offer not found")
+	ErrSecondChanceOfferClosed     = errors.New("second-chance offer was already answered")
+	ErrSecondChanceOfferExpired    = errors.New("second-chance offer has expired")
+	ErrSecondChanceStatusChanged   = errors.New("second-chance offer status changed, please retry")
 
 	// Bid errors
 	ErrBidAmountTooLow        = errors.New("bid amount must be higher than current highest bid")
 	ErrBidAmountInvalid       = errors.New("bid amount must be greater than 0")
 	ErrBidAmountBelowStarting = errors.New("bid amount must be higher than starting price")
+	ErrBidAmountTooHigh       = errors.New("bid amount must be lower than current lowest bid")
+	ErrBidAmountAboveStarting = errors.New("bid amount must be lower than starting price")
 	ErrNoBidsFound            = errors.New("no bids found")
 	ErrAuctionNotStarted      = errors.New("auction not started")
+	ErrMaxBidNotIncreased     = errors.New("max bid must be higher than your existing max bid")
+	ErrInvalidBidQuantity     = errors.New("bid quantity must be between 1 and the auction quantity")
+	ErrBidNotFound            = errors.New("bid not found")
+	ErrBidNotRetractable      = errors.New("only accepted bids on active auctions can be retracted")
+	ErrRetractionWindowClosed = errors.New("the retraction window for this bid has closed")
+	ErrNotBidOwner            = errors.New("only the bidder or an admin can retract a bid")
+	ErrRetractReasonRequired  = errors.New("a reason is required to retract a bid")
+	ErrSealedBidsHidden       = errors.New("sealed bids are revealed once the auction ends")
+
+	// Settlement errors
+	ErrOrderNotFound          = errors.New("order not found")
+	ErrOrderNotPayable        = errors.New("order is not awaiting payment")
+	ErrOrderExpired           = errors.New("order payment window has expired")
+	ErrOrderNotDue            = errors.New("order payment is not due yet")
+	ErrOrderStatusChanged     = errors.New("order status changed, please retry")
+	ErrOrderPaymentInProgress = errors.New("a payment for this order is already in progress")
+	ErrPaymentDeclined        = errors.New("payment declined")
+
+	// Ledger errors
+	ErrLedgerUnbalanced    = errors.New("ledger transaction does not balance")
+	ErrLedgerAlreadyPosted = errors.New("ledger transaction already posted")
 
 	// User errors
-	ErrUserNotFound = errors.New("user not found")
+	ErrUserNotFound        = errors.New("user not found")
+	ErrNotAdmin            = errors.New("only admins can perform this action")
+	ErrInvalidCreditLimit  = errors.New("credit limit must not be negative")
+	ErrCreditLimitExceeded = errors.New("bid exceeds your credit limit")
+	// ErrCreditCurrencyUnconvertible means a bid or open exposure has no exchange rate into the credit limit's currency
+	ErrCreditCurrencyUnconvertible = errors.New("bid cannot be checked against your credit limit: no exchange rate for its currency")
 
 	// Item errors
 	ErrItemNotFound = errors.New("item not found")
@@ -42,18 +105,82 @@ var (
 	// WebSocket message validation errors
 	ErrMessageTypeRequired   = errors.New("message type is required")
 	ErrAuctionIDRequired     = errors.New("auction_id is required")
+	ErrBidIDRequired         = errors.New("bid_id is required")
+	ErrOfferIDRequired       = errors.New("offer_id is required")
+	ErrOrderIDRequired       = errors.New("order_id is required")
+	ErrUserIDRequired        = errors.New("user_id is required")
 	ErrInvalidAmount         = errors.New("valid amount is required")
+	ErrInvalidMaxAmount      = errors.New("valid max_amount is required")
 	ErrItemIDRequired        = errors.New("item_id is required")
 	ErrStartTimeRequired     = errors.New("start_time is required")
 	ErrEndTimeRequired       = errors.New("end_time is required")
 	ErrStartingPriceRequired = errors.New("starting_price is required")
+	ErrInvalidSinceSeq       = errors.New("since_seq must be a non-negative integer")
 	ErrUnknownMessageType    = errors.New("unknown message type")
 
 	// Broadcasting errors
 	ErrBroadcastFailed   = errors.New("broadcast failed")
 	ErrUserNotSubscribed = errors.New("user not subscribed to auction")
+	ErrEventsExpired     = errors.New("missed events are no longer retained")
 
 	// WebSocket handler specific errors
 	ErrClientEventChannelNotFound = errors.New("client event channel not found")
 	ErrInvalidItemIDFormat        = errors.New("invalid item_id format")
+
+	// Money errors
+	ErrInvalidMoney      = errors.New("amount must be a decimal number with at most two decimals")
+	ErrInvalidCurrency   = errors.New("currency must be a three-letter ISO 4217 code")
+	ErrCurrencyMismatch  = errors.New("bid currency does not match the auction currency")
+	ErrFXRateUnavailable = errors.New("exchange rate unavailable")
 )
+
+// MinimumBidError is returned when a bid does not beat the current price by the increment.
+// It matches ErrBidAmountTooLow with errors.Is.
+type MinimumBidError struct {
+	MinNextBid Money
+}
+
+func (e *MinimumBidError) Error() string {
+	return fmt.Sprintf("bid amount must be at least %s", e.MinNextBid)
+}
+
+func (e *MinimumBidError) Is(target error) bool {
+	return target == ErrBidAmountTooLow
+}
+
+// MaximumBidError is the reverse auction counterpart of MinimumBidError.
+// It matches ErrBidAmountTooHigh with errors.Is.
+type MaximumBidError struct {
+	MaxNextBid Money
+}
+
+func (e *MaximumBidError) Error() string {
+	return fmt.Sprintf("bid amount must be at most %s", e.MaxNextBid)
+}
+
+func (e *MaximumBidError) Is(target error) bool {
+	return target == ErrBidAmountTooHigh
+}
+
+// NewNextBidError builds the error telling a bidder which amount the next bid must reach
+func NewNextBidError(nextBid Money, lowestWins bool) error {
+	if lowestWins {
+		return &MaximumBidError{MaxNextBid: nextBid}
+	}
+	return &MinimumBidError{MinNextBid: nextBid}
+}
+
+// CreditLimitError is returned when a bid would take a user's open exposure past their credit limit.
+// It matches ErrCreditLimitExceeded with errors.Is.
+type CreditLimitError struct {
+	CreditLimit Money
+	Exposure    Money
+}
+
+func (e *CreditLimitError) Error() string {
+	return fmt.Sprintf("bid exceeds your credit limit of %s (%s already committed)", e.CreditLimit, e.Exposure)
+}
+
+func (e *CreditLimitError) Is(target error) bool {
+	return target == ErrCreditLimitExceeded
+}
@@ -0,0 +1,282 @@
+package shared
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Currency is an ISO 4217 currency code
+type Currency string
+
+// DefaultCurrency is used for amounts that do not name a currency
+const DefaultCurrency Currency = "USD"
+
+// ParseCurrency reads a three-letter currency code such as "usd" or "EUR"
+func ParseCurrency(value string) (Currency, error) {
+	currency := Currency(strings.ToUpper(strings.TrimSpace(value)))
+	if !currency.Valid() {
+		return "", ErrInvalidCurrency
+	}
+	return currency, nil
+}
+
+// Valid returns true if the currency is written as three upper-case letters
+func (c Currency) Valid() bool {
+	if len(c) != 3 {
+		return false
+	}
+	for _, r := range c {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// minorUnitsPerMajor is the number of minor units in a major unit; every currency is
+// stored with two decimals, matching the DECIMAL(_,2) columns
+const minorUnitsPerMajor = 100
+
+/*
+Money is an exact amount of a currency, held as an integer number of minor units (cents).
+ 1. Arithmetic and comparisons are exact; only Scale rounds, to the nearest minor unit
+ 2. Operands of arithmetic and comparisons are expected to share a currency, see SameCurrency
+ 3. JSON and SQL carry the amount as a decimal string such as "150.00"; the currency travels separately
+
+The zero value is zero in the default currency.
+*/
+type Money struct {
+	minor    int64
+	currency Currency
+}
+
+// NewMoney creates an amount from minor units
+func NewMoney(minor int64, currency Currency) Money {
+	return Money{minor: minor, currency: currency}
+}
+
+// ParseMoney reads a decimal amount such as "150", "150.5" or "-3.25" exactly
+func ParseMoney(value string, currency Currency) (Money, error) {
+	value = strings.TrimSpace(value)
+	negative := strings.HasPrefix(value, "-")
+	value = strings.TrimPrefix(value, "-")
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	if whole == "" || len(fraction) > 2 || strings.ContainsAny(whole+fraction, "+-") {
+		return Money{}, ErrInvalidMoney
+	}
+	fraction += strings.Repeat("0", 2-len(fraction))
+
+	major, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return Money{}, ErrInvalidMoney
+	}
+	cents, err := strconv.ParseInt(fraction, 10, 64)
+	if err != nil {
+		return Money{}, ErrInvalidMoney
+	}
+	if major > (math.MaxInt64-cents)/minorUnitsPerMajor {
+		return Money{}, ErrInvalidMoney
+	}
+
+	minor := major*minorUnitsPerMajor + cents
+	if negative {
+		minor = -minor
+	}
+	return Money{minor: minor, currency: currency}, nil
+}
+
+// MoneyFromFloat reads an amount decoded from a JSON number. The shortest decimal
+// representation of the float is used, so 150.1 reads as exactly 150.10.
+func MoneyFromFloat(value float64, currency Currency) (Money, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return Money{}, ErrInvalidMoney
+	}
+	return ParseMoney(strconv.FormatFloat(value, 'f', -1, 64), currency)
+}
+
+// Minor returns the amount in minor units
+func (m Money) Minor() int64 {
+	return m.minor
+}
+
+// Currency returns the currency of the amount
+func (m Money) Currency() Currency {
+	if m.currency == "" {
+		return DefaultCurrency
+	}
+	return m.currency
+}
+
+// WithCurrency returns the same amount in another currency, without conversion
+func (m Money) WithCurrency(currency Currency) Money {
+	return Money{minor: m.minor, currency: currency}
+}
+
+// SameCurrency returns true if both amounts are in the same currency
+func (m Money) SameCurrency(other Money) bool {
+	return m.Currency() == other.Currency()
+}
+
+// IsZero returns true if the amount is zero
+func (m Money) IsZero() bool {
+	return m.minor == 0
+}
+
+// IsPositive returns true if the amount is above zero
+func (m Money) IsPositive() bool {
+	return m.minor > 0
+}
+
+// IsNegative returns true if the amount is below zero
+func (m Money) IsNegative() bool {
+	return m.minor < 0
+}
+
+// Cmp returns -1, 0 or 1 if the amount is below, equal to or above other
+func (m Money) Cmp(other Money) int {
+	switch {
+	case m.minor < other.minor:
+		return -1
+	case m.minor > other.minor:
+		return 1
+	}
+	return 0
+}
+
+// Equal returns true if both amounts are the same
+func (m Money) Equal(other Money) bool {
+	return m.minor == other.minor
+}
+
+// LessThan returns true if the amount is below other
+func (m Money) LessThan(other Money) bool {
+	return m.minor < other.minor
+}
+
+// GreaterThan returns true if the amount is above other
+func (m Money) GreaterThan(other Money) bool {
+	return m.minor > other.minor
+}
+
+// Add returns the sum of both amounts
+func (m Money) Add(other Money) Money {
+	return Money{minor: m.minor + other.minor, currency: m.currency}
+}
+
+// Sub returns the amount minus other
+func (m Money) Sub(other Money) Money {
+	return Money{minor: m.minor - other.minor, currency: m.currency}
+}
+
+// Mul returns the amount times a whole number, e.g. a unit price times a quantity
+func (m Money) Mul(n int) Money {
+	return Money{minor: m.minor * int64(n), currency: m.currency}
+}
+
+// Neg returns the amount with the opposite sign
+func (m Money) Neg() Money {
+	return Money{minor: -m.minor, currency: m.currency}
+}
+
+// Scale returns the amount times factor, rounded half away from zero to the nearest minor unit
+func (m Money) Scale(factor float64) Money {
+	return Money{minor: int64(math.Round(float64(m.minor) * factor)), currency: m.currency}
+}
+
+// Convert returns the amount in another currency, where rate is the price of one unit of the
+// amount's currency in the target currency, rounded to the nearest minor unit
+func (m Money) Convert(rate float64, to Currency) Money {
+	return m.Scale(rate).WithCurrency(to)
+}
+
+// Percent returns percent of the amount, rounded to the nearest minor unit
+func (m Money) Percent(percent float64) Money {
+	return m.Scale(percent / 100)
+}
+
+// MaxMoney returns the larger of two amounts
+func MaxMoney(a, b Money) Money {
+	if b.GreaterThan(a) {
+		return b
+	}
+	return a
+}
+
+// MinMoney returns the smaller of two amounts
+func MinMoney(a, b Money) Money {
+	if b.LessThan(a) {
+		return b
+	}
+	return a
+}
+
+// String formats the amount with two decimals, e.g. "150.00"
+func (m Money) String() string {
+	minor := m.minor
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, minor/minorUnitsPerMajor, minor%minorUnitsPerMajor)
+}
+
+// MarshalJSON writes the amount as a decimal string
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON reads a decimal string, or a number for payloads written before amounts were strings.
+// The currency of the receiver is kept.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		var number json.Number
+		if err := json.Unmarshal(data, &number); err != nil {
+			return ErrInvalidMoney
+		}
+		text = number.String()
+	}
+
+	parsed, err := ParseMoney(text, m.currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value stores the amount as an exact decimal string
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan reads an amount from a DECIMAL column. The currency of the receiver is kept.
+func (m *Money) Scan(src interface{}) error {
+	var text string
+	switch v := src.(type) {
+	case []byte:
+		text = string(v)
+	case string:
+		text = v
+	case int64:
+		*m = Money{minor: v * minorUnitsPerMajor, currency: m.currency}
+		return nil
+	case float64:
+		text = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Errorf("cannot scan %T into money", src)
+	}
+
+	parsed, err := ParseMoney(text, m.currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
@@ -0,0 +1,164 @@
+package shared
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{name: "whole", value: "150", want: 15000},
+		{name: "one decimal", value: "150.5", want: 15050},
+		{name: "two decimals", value: "150.55", want: 15055},
+		{name: "trailing dot", value: "150.", want: 15000},
+		{name: "negative", value: "-3.25", want: -325},
+		{name: "surrounding spaces", value: " 7.10 ", want: 710},
+		{name: "zero", value: "0.00", want: 0},
+		{name: "empty", value: "", wantErr: true},
+		{name: "missing whole part", value: ".50", wantErr: true},
+		{name: "three decimals", value: "1.005", wantErr: true},
+		{name: "plus sign", value: "+1", wantErr: true},
+		{name: "double minus", value: "--1", wantErr: true},
+		{name: "minus in fraction", value: "1.-5", wantErr: true},
+		{name: "exponent", value: "1e3", wantErr: true},
+		{name: "letters", value: "abc", wantErr: true},
+		{name: "overflow", value: "92233720368547758.08", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMoney(tt.value, "EUR")
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidMoney) {
+					t.Fatalf("ParseMoney(%q) error = %v, want ErrInvalidMoney", tt.value, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMoney(%q) error = %v", tt.value, err)
+			}
+			if got.Minor() != tt.want || got.Currency() != "EUR" {
+				t.Errorf("ParseMoney(%q) = %d %s, want %d EUR", tt.value, got.Minor(), got.Currency(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		money Money
+		json  string
+	}{
+		{name: "whole", money: NewMoney(15000, "USD"), json: `"150.00"`},
+		{name: "cents", money: NewMoney(15005, "USD"), json: `"150.05"`},
+		{name: "below one", money: NewMoney(7, "USD"), json: `"0.07"`},
+		{name: "negative", money: NewMoney(-325, "USD"), json: `"-3.25"`},
+		{name: "zero", money: NewMoney(0, "USD"), json: `"0.00"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.money)
+			if err != nil {
+				t.Fatalf("Marshal error = %v", err)
+			}
+			if string(data) != tt.json {
+				t.Errorf("Marshal = %s, want %s", data, tt.json)
+			}
+
+			got := NewMoney(0, "USD")
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal(%s) error = %v", data, err)
+			}
+			if !got.Equal(tt.money) || !got.SameCurrency(tt.money) {
+				t.Errorf("Unmarshal(%s) = %s %s, want %s %s", data, got, got.Currency(), tt.money, tt.money.Currency())
+			}
+		})
+	}
+}
+
+func TestMoneyUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    int64
+		wantErr bool
+	}{
+		{name: "string", json: `"12.34"`, want: 1234},
+		{name: "legacy number", json: `12.34`, want: 1234},
+		{name: "legacy whole number", json: `12`, want: 1200},
+		{name: "too precise", json: `"12.345"`, wantErr: true},
+		{name: "not a number", json: `true`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewMoney(0, "GBP")
+			err := json.Unmarshal([]byte(tt.json), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Unmarshal(%s) = %s, want an error", tt.json, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unmarshal(%s) error = %v", tt.json, err)
+			}
+			if got.Minor() != tt.want || got.Currency() != "GBP" {
+				t.Errorf("Unmarshal(%s) = %d %s, want %d GBP", tt.json, got.Minor(), got.Currency(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyScan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    int64
+		wantErr bool
+	}{
+		{name: "bytes", src: []byte("150.25"), want: 15025},
+		{name: "string", src: "0.99", want: 99},
+		{name: "int64", src: int64(42), want: 4200},
+		{name: "float64", src: 150.1, want: 15010},
+		{name: "malformed", src: "1.234", wantErr: true},
+		{name: "unsupported type", src: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewMoney(0, "JPY")
+			err := got.Scan(tt.src)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Scan(%v) = %s, want an error", tt.src, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Scan(%v) error = %v", tt.src, err)
+			}
+			if got.Minor() != tt.want || got.Currency() != "JPY" {
+				t.Errorf("Scan(%v) = %d %s, want %d JPY", tt.src, got.Minor(), got.Currency(), tt.want)
+			}
+
+			// Value must read back to the same amount
+			value, err := got.Value()
+			if err != nil {
+				t.Fatalf("Value error = %v", err)
+			}
+			back := NewMoney(0, "JPY")
+			if err := back.Scan(value); err != nil || !back.Equal(got) {
+				t.Errorf("Scan(Value()) = %s, %v, want %s", back, err, got)
+			}
+		})
+	}
+}
@@ -2,10 +2,55 @@ package shared
 
 import "github.com/google/uuid"
 
-// AuctionEndResult represents the result of ending an auction
+// AuctionResultReserveNotMet is the result status of an auction whose highest bid stayed below the reserve
+const AuctionResultReserveNotMet = "reserve_not_met"
+
+// Reasons an auction ended
+const (
+	EndReasonExpired = "expired"
+	EndReasonBuyNow  = "buy_now"
+	// EndReasonPriceAccepted is used when a buyer accepts the current price of a Dutch auction
+	EndReasonPriceAccepted = "price_accepted"
+	// EndReasonSecondChance is used when a runner-up accepts a second-chance offer after the winner defaulted
+	EndReasonSecondChance = "second_chance"
+)
+
+// BidRanking is a bidder's position in the results revealed when a sealed auction closes
+type BidRanking struct {
+	Rank   int       `json:"rank"`
+	UserID uuid.UUID `json:"user_id"`
+	Amount Money     `json:"amount"`
+}
+
+// AuctionWinner is a bidder who won units of an auction and the price they pay per unit
+type AuctionWinner struct {
+	UserID   uuid.UUID `json:"user_id"`
+	BidID    uuid.UUID `json:"bid_id"`
+	Quantity int       `json:"quantity"`
+	Price    Money     `json:"price"`
+}
+
+// AuctionEndResult represents the result of ending an auction.
+// Single-unit auctions have at most one winner; multi-unit auctions may have several.
 type AuctionEndResult struct {
-	AuctionID  uuid.UUID
-	WinnerID   *uuid.UUID
-	FinalPrice *float64
-	Status     string
+	AuctionID uuid.UUID
+	Winners   []AuctionWinner
+	Status    string
+	Reason    string
+	// Rankings is only set for sealed auctions
+	Rankings []BidRanking
+}
+
+// HasWinner returns true if at least one unit was sold
+func (r *AuctionEndResult) HasWinner() bool {
+	return len(r.Winners) > 0
+}
+
+// UnitsSold returns the total quantity allocated to winners
+func (r *AuctionEndResult) UnitsSold() int {
+	units := 0
+	for _, winner := range r.Winners {
+		units += winner.Quantity
+	}
+	return units
 }
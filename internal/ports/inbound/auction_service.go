@@ -5,6 +5,9 @@ import (
 
 	"troffee-auction-service/internal/domain/auction"
 	"troffee-auction-service/internal/domain/bid"
+	"troffee-auction-service/internal/domain/ledger"
+	"troffee-auction-service/internal/domain/order"
+	"troffee-auction-service/internal/domain/shared"
 
 	"github.com/google/uuid"
 )
@@ -22,6 +25,21 @@ type AuctionService interface {
 
 	// EndAuction ends an auction
 	EndAuction(ctx context.Context, auctionID uuid.UUID) error
+
+	// UpdateAuction changes the terms of an auction that has no bids yet
+	UpdateAuction(ctx context.Context, req UpdateAuctionRequest) (*auction.Auction, error)
+
+	// CancelAuction withdraws an auction on behalf of its creator or an admin
+	CancelAuction(ctx context.Context, req CancelAuctionRequest) (*auction.Auction, error)
+
+	// OfferSecondChance offers the item of an ended auction to the next runner-up after the winner defaulted
+	OfferSecondChance(ctx context.Context, req SecondChanceOfferRequest) (*auction.SecondChanceOffer, error)
+
+	// AcceptSecondChanceOffer sells the item to the runner-up at the offered price
+	AcceptSecondChanceOffer(ctx context.Context, req SecondChanceAnswerRequest) (*shared.AuctionEndResult, error)
+
+	// DeclineSecondChanceOffer turns the offer down so the seller can offer the item to the next bidder
+	DeclineSecondChanceOffer(ctx context.Context, req SecondChanceAnswerRequest) (*auction.SecondChanceOffer, error)
 }
 
 // BidService defines the interface for bid operations
@@ -29,20 +47,75 @@ type BidService interface {
 	// PlaceBid places a new bid on an auction
 	PlaceBid(ctx context.Context, req PlaceBidRequest) (*bid.Bid, error)
 
-	// GetBids retrieves bids for an auction
+	// PlaceMaxBid stores a hidden maximum bid and bids on the user's behalf up to it
+	PlaceMaxBid(ctx context.Context, req PlaceMaxBidRequest) (*bid.ProxyBid, error)
+
+	// BuyNow ends an auction immediately for the buyer at its buy-now price
+	BuyNow(ctx context.Context, req BuyNowRequest) (*shared.AuctionEndResult, error)
+
+	// AcceptPrice ends a Dutch auction for the buyer at its current price
+	AcceptPrice(ctx context.Context, req AcceptPriceRequest) (*shared.AuctionEndResult, error)
+
+	// RetractBid withdraws an accepted bid on behalf of its bidder or an admin
+	RetractBid(ctx context.Context, req RetractBidRequest) (*bid.Retraction, error)
+
+	// GetBids retrieves bids for an auction; sealed bids are hidden until it ends
 	GetBids(ctx context.Context, auctionID uuid.UUID) ([]*bid.Bid, error)
 
-	// GetHighestBid retrieves the highest bid for an auction
-	GetHighestBid(ctx context.Context, auctionID uuid.UUID) (*bid.Bid, error)
+	// GetBestBid retrieves the currently winning bid for an auction
+	GetBestBid(ctx context.Context, auctionID uuid.UUID) (*bid.Bid, error)
+}
+
+// SettlementService defines the interface for settling ended auctions
+type SettlementService interface {
+	// PayOrder charges the buyer for an order awaiting payment
+	PayOrder(ctx context.Context, req PayOrderRequest) (*order.Order, error)
+}
+
+// LedgerService defines the interface for account queries
+type LedgerService interface {
+	// GetBalances returns the balances of a user's account, one per currency
+	GetBalances(ctx context.Context, userID uuid.UUID) ([]*ledger.Balance, error)
+
+	// GetStatement returns a page of the entries posted to a user's account, newest first
+	GetStatement(ctx context.Context, req StatementRequest) ([]*ledger.Entry, error)
+}
+
+// UserService defines the interface for user administration
+type UserService interface {
+	// SetCreditLimit sets how much a user may have committed in winning bids at once
+	SetCreditLimit(ctx context.Context, req SetCreditLimitRequest) (*shared.User, error)
 }
 
 // request to create an auction
 type CreateAuctionRequest struct {
-	ItemID        uuid.UUID `json:"item_id"`
-	CreatorID     uuid.UUID `json:"creator_id"`
-	StartTime     string    `json:"start_time"`
-	EndTime       string    `json:"end_time"`
-	StartingPrice float64   `json:"starting_price"`
+	ItemID           uuid.UUID     `json:"item_id"`
+	CreatorID        uuid.UUID     `json:"creator_id"`
+	StartTime        string        `json:"start_time"`
+	EndTime          string        `json:"end_time"`
+	StartingPrice    shared.Money  `json:"starting_price"`
+	SoftCloseSeconds int           `json:"soft_close_seconds"`
+	ReservePrice     *shared.Money `json:"reserve_price,omitempty"`
+	BuyNowPrice      *shared.Money `json:"buy_now_price,omitempty"`
+
+	// Currency of every price of the auction and of its bids, shared.DefaultCurrency when unset
+	Currency shared.Currency `json:"currency,omitempty"`
+
+	// IncrementRule is the minimum step between bids, flat or tiered by price
+	IncrementRule *auction.IncrementRule `json:"increment_rule,omitempty"`
+
+	// Quantity of identical units for sale (1 when unset) and how multi-unit winners pay
+	Quantity int             `json:"quantity,omitempty"`
+	Pricing  auction.Pricing `json:"pricing,omitempty"`
+
+	// Type defaults to an English auction; Dutch auctions also need a price drop schedule
+	Type                     auction.Type `json:"type,omitempty"`
+	PriceDropAmount          shared.Money `json:"price_drop_amount"`
+	PriceDropIntervalSeconds int          `json:"price_drop_interval_seconds,omitempty"`
+	FloorPrice               shared.Money `json:"floor_price"`
+
+	// RelistPolicy relists the auction automatically when it ends unsold
+	RelistPolicy *auction.RelistPolicy `json:"relist_policy,omitempty"`
 }
 
 // request to list auctions
@@ -52,10 +125,93 @@ type ListAuctionsRequest struct {
 	PageSize int             `json:"page_size"`
 }
 
-// request to place a bid
+// request to place a bid; ClientID names the WebSocket connection and is empty over HTTP
 type PlaceBidRequest struct {
+	AuctionID uuid.UUID    `json:"auction_id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	ClientID  string       `json:"client_id"`
+	Amount    shared.Money `json:"amount"`
+	// Currency of the amount; it must match the auction's and is assumed to when unset
+	Currency shared.Currency `json:"currency,omitempty"`
+	// Quantity is the number of units wanted in a multi-unit auction, 1 when unset
+	Quantity int `json:"quantity,omitempty"`
+}
+
+// request to place a proxy (maximum) bid
+type PlaceMaxBidRequest struct {
+	AuctionID uuid.UUID    `json:"auction_id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	ClientID  string       `json:"client_id"`
+	MaxAmount shared.Money `json:"max_amount"`
+	// Currency of the maximum; it must match the auction's and is assumed to when unset
+	Currency shared.Currency `json:"currency,omitempty"`
+}
+
+// request to buy an auction at its buy-now price
+type BuyNowRequest struct {
+	AuctionID uuid.UUID `json:"auction_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	ClientID  string    `json:"client_id"`
+}
+
+// request to accept the current price of a Dutch auction
+type AcceptPriceRequest struct {
 	AuctionID uuid.UUID `json:"auction_id"`
 	UserID    uuid.UUID `json:"user_id"`
 	ClientID  string    `json:"client_id"`
-	Amount    float64   `json:"amount"`
+}
+
+// request to retract a bid; bidders may only retract shortly after bidding
+type RetractBidRequest struct {
+	AuctionID uuid.UUID `json:"auction_id"`
+	BidID     uuid.UUID `json:"bid_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Reason    string    `json:"reason"`
+}
+
+// request to cancel an auction; a reason is required once bids were placed
+type CancelAuctionRequest struct {
+	AuctionID uuid.UUID `json:"auction_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Reason    string    `json:"reason,omitempty"`
+}
+
+// request to edit an auction before its first bid; nil fields are left unchanged
+type UpdateAuctionRequest struct {
+	AuctionID     uuid.UUID     `json:"auction_id"`
+	UserID        uuid.UUID     `json:"user_id"`
+	StartingPrice *shared.Money `json:"starting_price,omitempty"`
+	EndTime       *string       `json:"end_time,omitempty"`
+}
+
+// request by a seller to offer the item to the runner-up of an ended auction
+type SecondChanceOfferRequest struct {
+	AuctionID uuid.UUID `json:"auction_id"`
+	UserID    uuid.UUID `json:"user_id"`
+}
+
+// request by a runner-up to accept or decline a second-chance offer
+type SecondChanceAnswerRequest struct {
+	OfferID uuid.UUID `json:"offer_id"`
+	UserID  uuid.UUID `json:"user_id"`
+}
+
+// request by a buyer to pay for an order
+type PayOrderRequest struct {
+	OrderID uuid.UUID `json:"order_id"`
+	UserID  uuid.UUID `json:"user_id"`
+}
+
+// request for a page of a user's account statement
+type StatementRequest struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Page     int       `json:"page"`
+	PageSize int       `json:"page_size"`
+}
+
+// request by an admin to set a user's credit limit; a nil limit removes it
+type SetCreditLimitRequest struct {
+	AdminID     uuid.UUID     `json:"admin_id"`
+	UserID      uuid.UUID     `json:"user_id"`
+	CreditLimit *shared.Money `json:"credit_limit"`
 }
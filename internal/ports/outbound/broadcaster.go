@@ -2,6 +2,10 @@ package outbound
 
 import (
 	"context"
+	"time"
+
+	"troffee-auction-service/internal/domain/order"
+	"troffee-auction-service/internal/domain/shared"
 
 	"github.com/google/uuid"
 )
@@ -10,20 +14,98 @@ import (
 type EventType string
 
 const (
-	EventTypeAuctionCreated EventType = "auction.created"
-	EventTypeBidPlaced      EventType = "bid.placed"
-	EventTypeAuctionEnded   EventType = "auction.ended"
-	EventTypeError          EventType = "error"
+	EventTypeAuctionCreated   EventType = "auction.created"
+	EventTypeAuctionStarted   EventType = "auction.started"
+	EventTypeBidPlaced        EventType = "bid.placed"
+	EventTypeBidRetracted     EventType = "bid.retracted"
+	EventTypeAuctionEnded     EventType = "auction.ended"
+	EventTypeAuctionExtended  EventType = "auction.extended"
+	EventTypeAuctionCancelled EventType = "auction.cancelled"
+	EventTypeAuctionUpdated   EventType = "auction.updated"
+	EventTypeAuctionRelisted  EventType = "auction.relisted"
+	EventTypePriceDropped     EventType = "auction.price_dropped"
+
+	// Second-chance offers are addressed to single users rather than auction subscribers
+	EventTypeSecondChanceOffered  EventType = "auction.second_chance_offered"
+	EventTypeSecondChanceAnswered EventType = "auction.second_chance_answered"
+
+	// Order events are addressed to the buyer only
+	EventTypeOrderCreated       EventType = "order.created"
+	EventTypeOrderPaid          EventType = "order.paid"
+	EventTypeOrderPaymentFailed EventType = "order.payment_failed"
+	EventTypeOrderExpired       EventType = "order.expired"
+
+	EventTypeError EventType = "error"
 )
 
 // Event represents a broadcast event
 type Event struct {
+	// Seq numbers the events of an auction from 1 without gaps and is set when the event is
+	// published; user events have none
+	Seq       int64                  `json:"seq,omitempty"`
 	Type      EventType              `json:"type"`
 	AuctionID uuid.UUID              `json:"auction_id"`
 	Data      map[string]interface{} `json:"data"`
 	Timestamp int64                  `json:"timestamp"`
 }
 
+// NewAuctionEndedEvent builds the auction.ended event announcing an auction result
+func NewAuctionEndedEvent(result *shared.AuctionEndResult) Event {
+	eventData := map[string]interface{}{
+		"auction_id": result.AuctionID.String(),
+		"status":     result.Status,
+	}
+	if result.Reason != "" {
+		eventData["reason"] = result.Reason
+	}
+	if result.HasWinner() {
+		eventData["winners"] = result.Winners
+	}
+	// Single winners are also flattened for clients of single-unit auctions
+	if len(result.Winners) == 1 {
+		eventData["winner_id"] = result.Winners[0].UserID.String()
+		eventData["final_price"] = result.Winners[0].Price
+	}
+	if len(result.Rankings) > 0 {
+		eventData["rankings"] = result.Rankings
+	}
+
+	return Event{
+		Type:      EventTypeAuctionEnded,
+		AuctionID: result.AuctionID,
+		Data:      eventData,
+		Timestamp: time.Now().Unix(),
+	}
+}
+
+// NewOrderEvent builds the event telling a buyer about the state of their order
+func NewOrderEvent(eventType EventType, o *order.Order) Event {
+	eventData := map[string]interface{}{
+		"order_id":      o.ID.String(),
+		"auction_id":    o.AuctionID.String(),
+		"quantity":      o.Quantity,
+		"unit_price":    o.UnitPrice,
+		"hammer_price":  o.HammerPrice,
+		"buyer_premium": o.BuyerPremium,
+		"amount":        o.Amount,
+		"status":        string(o.Status),
+		"due_at":        o.DueAt.Format(time.RFC3339),
+	}
+	if o.PaymentReference != "" {
+		eventData["payment_reference"] = o.PaymentReference
+	}
+	if o.FailureReason != "" {
+		eventData["failure_reason"] = o.FailureReason
+	}
+
+	return Event{
+		Type:      eventType,
+		AuctionID: o.AuctionID,
+		Data:      eventData,
+		Timestamp: time.Now().Unix(),
+	}
+}
+
 // Broadcaster defines the interface for broadcasting events
 type Broadcaster interface {
 	// Subscribe subscribes a client to events for a specific auction
@@ -33,9 +115,31 @@ type Broadcaster interface {
 	// Unsubscribe unsubscribes a client from events for a specific auction
 	Unsubscribe(ctx context.Context, auctionID uuid.UUID, clientID string) error
 
+	// UnsubscribeClient drops every subscription of a client, user events included. Nothing is
+	// delivered to its event channel once it returns; the channel is never closed by the broadcaster.
+	UnsubscribeClient(ctx context.Context, clientID string) error
+
+	// Watch delivers the events of an auction to a read-only watcher. Watchers of the same
+	// auction share one subscription instead of holding a connection each.
+	Watch(ctx context.Context, auctionID uuid.UUID, eventChan chan Event) error
+
+	// Unwatch stops delivering an auction's events to a watcher's channel. Nothing is delivered
+	// to it once Unwatch returns; the channel is never closed by the broadcaster.
+	Unwatch(ctx context.Context, auctionID uuid.UUID, eventChan chan Event) error
+
 	// Publish publishes an event to all subscribers of an auction
 	Publish(ctx context.Context, auctionID uuid.UUID, event Event) error
 
+	// EventsSince returns the events of an auction numbered after sinceSeq, oldest first.
+	// It fails with shared.ErrEventsExpired when some of them are no longer retained.
+	EventsSince(ctx context.Context, auctionID uuid.UUID, sinceSeq int64) ([]Event, error)
+
+	// SubscribeUser subscribes a client to the events addressed to its user, on the same channel as its auctions
+	SubscribeUser(ctx context.Context, userID uuid.UUID, clientID string, eventChan chan Event) error
+
+	// PublishToUser publishes an event to every connected client of a single user
+	PublishToUser(ctx context.Context, userID uuid.UUID, event Event) error
+
 	// GetSubscribers returns the list of client IDs subscribed to an auction
 	GetSubscribers(ctx context.Context, auctionID uuid.UUID) ([]string, error)
 
@@ -0,0 +1,15 @@
+package outbound
+
+import (
+	"context"
+
+	"troffee-auction-service/internal/domain/shared"
+)
+
+// FXRateProvider defines the interface for looking up exchange rates used to show
+// amounts in a client's preferred currency. Conversions are for display only; bids
+// and orders always stay in the auction's currency.
+type FXRateProvider interface {
+	// Rate returns how many units of to one unit of from buys, or ErrFXRateUnavailable
+	Rate(ctx context.Context, from, to shared.Currency) (float64, error)
+}
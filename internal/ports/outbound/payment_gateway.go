@@ -0,0 +1,31 @@
+package outbound
+
+import (
+	"context"
+
+	"troffee-auction-service/internal/domain/shared"
+
+	"github.com/google/uuid"
+)
+
+// PaymentRequest asks a payment provider to collect an order's amount from the buyer
+type PaymentRequest struct {
+	// OrderID identifies the charge; gateways use it so a retried charge is not collected twice
+	OrderID     uuid.UUID
+	PayerID     uuid.UUID
+	PayeeID     uuid.UUID
+	Amount      shared.Money
+	Description string
+}
+
+// PaymentResult is a successful charge
+type PaymentResult struct {
+	// Reference is the provider's identifier of the charge
+	Reference string
+}
+
+// PaymentGateway defines the interface for collecting payments from buyers
+type PaymentGateway interface {
+	// Charge collects a payment, returning an error when it is declined or fails
+	Charge(ctx context.Context, req PaymentRequest) (*PaymentResult, error)
+}
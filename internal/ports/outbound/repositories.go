@@ -2,9 +2,12 @@ package outbound
 
 import (
 	"context"
+	"time"
 
 	"troffee-auction-service/internal/domain/auction"
 	"troffee-auction-service/internal/domain/bid"
+	"troffee-auction-service/internal/domain/ledger"
+	"troffee-auction-service/internal/domain/order"
 	"troffee-auction-service/internal/domain/shared"
 
 	"github.com/google/uuid"
@@ -27,6 +30,27 @@ type AuctionRepository interface {
 	// Update updates an auction
 	Update(ctx context.Context, auction *auction.Auction) error
 
+	// UpdateStatusWithOCC moves an auction to a new status only if it still has the expected one
+	UpdateStatusWithOCC(ctx context.Context, auctionID uuid.UUID, expected, status auction.Status) error
+
+	// CancelWithOCC cancels an auction only if it still has the expected status, rejecting its
+	// outstanding bids and dropping its proxy bids in the same transaction. Without a reason an
+	// auction with bids is not cancelled. Returns the number of rejected bids.
+	CancelWithOCC(ctx context.Context, auctionID uuid.UUID, expected auction.Status, reasonGiven bool) (int, error)
+
+	// EndWithOCC ends an active auction whose end time has passed by now. In the same transaction
+	// resolve decides the outcome from the auction and all of its bids, and may set the final price.
+	// Fails with ErrAuctionNotEnded when a late bid pushed the end time forward, and with
+	// ErrAuctionAlreadyEnded or ErrAuctionStatusChanged when the auction is no longer active.
+	EndWithOCC(ctx context.Context, auctionID uuid.UUID, now time.Time, resolve func(a *auction.Auction, bids []*bid.Bid) *shared.AuctionEndResult) (*shared.AuctionEndResult, error)
+
+	// UpdatePriceWithOCC sets the current price of an active auction only if it still has the expected price
+	UpdatePriceWithOCC(ctx context.Context, auctionID uuid.UUID, expectedCurrentPrice, newPrice shared.Money) error
+
+	// UpdateTermsWithOCC saves edited starting price, current price and end time of an open
+	// auction only if it still has the expected price and no bids
+	UpdateTermsWithOCC(ctx context.Context, auction *auction.Auction, expectedCurrentPrice shared.Money) error
+
 	// Delete deletes an auction
 	Delete(ctx context.Context, id uuid.UUID) error
 }
@@ -42,14 +66,81 @@ type BidRepository interface {
 	// GetByAuctionID retrieves all bids for an auction
 	GetByAuctionID(ctx context.Context, auctionID uuid.UUID) ([]*bid.Bid, error)
 
-	// GetHighestBid retrieves the highest bid for an auction
-	GetHighestBid(ctx context.Context, auctionID uuid.UUID) (*bid.Bid, error)
+	// GetBestBid retrieves the winning bid for an auction: the lowest one
+	// when lowestWins is set (reverse auctions), the highest one otherwise
+	GetBestBid(ctx context.Context, auctionID uuid.UUID, lowestWins bool) (*bid.Bid, error)
 
 	// Update updates a bid
 	Update(ctx context.Context, bid *bid.Bid) error
 
-	// PlaceBidWithOCC places a bid using optimistic concurrency control
-	PlaceBidWithOCC(ctx context.Context, bid *bid.Bid, expectedCurrentPrice float64) error
+	// PlaceBidWithOCC places a bid using optimistic concurrency control and returns the proxy
+	// bids placed automatically in response, and the soft-close extension the bid caused if any
+	PlaceBidWithOCC(ctx context.Context, bid *bid.Bid, expectedCurrentPrice shared.Money) ([]*bid.Bid, *auction.Extension, error)
+
+	// PlaceMaxBidWithOCC stores a proxy (maximum) bid and returns the visible bids
+	// produced by resolving it against competing proxies, and the soft-close extension they caused if any
+	PlaceMaxBidWithOCC(ctx context.Context, proxy *bid.ProxyBid, expectedCurrentPrice shared.Money) ([]*bid.Bid, *auction.Extension, error)
+
+	// PlaceWinningBidWithOCC places a bid that immediately wins and ends the auction
+	PlaceWinningBidWithOCC(ctx context.Context, bid *bid.Bid, expectedCurrentPrice shared.Money) error
+
+	// PlaceSealedBid records a hidden bid without changing the auction's visible price
+	PlaceSealedBid(ctx context.Context, bid *bid.Bid) error
+
+	// PlaceMultiUnitBid places a bid for units of a multi-unit auction and
+	// returns the new price a bid has to beat to win units, and the soft-close extension the bid caused if any
+	PlaceMultiUnitBid(ctx context.Context, bid *bid.Bid) (shared.Money, *auction.Extension, error)
+
+	// RetractBid withdraws an accepted bid, recomputes the auction price from the remaining
+	// bids and records the retraction in one transaction. It fills in the retraction's prices.
+	RetractBid(ctx context.Context, retraction *bid.Retraction) error
+
+	// GetOpenExposure sums what a user stands to pay on active auctions ahead of a new bid on
+	// auctionID, one amount per currency; a lead on that auction is left out unless it is a multi-unit auction
+	GetOpenExposure(ctx context.Context, userID, auctionID uuid.UUID) ([]shared.Money, error)
+}
+
+// SecondChanceOfferRepository defines the interface for second-chance offer data operations
+type SecondChanceOfferRepository interface {
+	// Create creates a new offer
+	Create(ctx context.Context, offer *auction.SecondChanceOffer) error
+
+	// GetByID retrieves an offer by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*auction.SecondChanceOffer, error)
+
+	// GetByAuctionID retrieves all offers made for an auction, oldest first
+	GetByAuctionID(ctx context.Context, auctionID uuid.UUID) ([]*auction.SecondChanceOffer, error)
+
+	// UpdateStatusWithOCC moves an offer to a new status only if it still has the expected one
+	UpdateStatusWithOCC(ctx context.Context, offerID uuid.UUID, expected, status auction.OfferStatus) error
+}
+
+// OrderRepository defines the interface for order data operations
+type OrderRepository interface {
+	// Create creates a new order
+	Create(ctx context.Context, order *order.Order) error
+
+	// GetByID retrieves an order by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*order.Order, error)
+
+	// GetByAuctionID retrieves all orders opened for an auction, oldest first
+	GetByAuctionID(ctx context.Context, auctionID uuid.UUID) ([]*order.Order, error)
+
+	// UpdateWithOCC saves an order's payment state only if it still has the expected status
+	UpdateWithOCC(ctx context.Context, order *order.Order, expected order.Status) error
+
+	// UpdatePaidWithOCC saves a paid order only if it still has the expected status and posts its
+	// settlement, when given, to the ledger in the same transaction
+	UpdatePaidWithOCC(ctx context.Context, order *order.Order, expected order.Status, settlement *ledger.Transaction) error
+}
+
+// LedgerRepository defines the interface for ledger data operations
+type LedgerRepository interface {
+	// GetUserBalances sums the entries posted to a user's account, one balance per currency
+	GetUserBalances(ctx context.Context, userID uuid.UUID) ([]*ledger.Balance, error)
+
+	// GetUserEntries retrieves the entries posted to a user's account, newest first
+	GetUserEntries(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]*ledger.Entry, error)
 }
 
 // ItemRepository defines the interface for item data operations
@@ -74,4 +165,7 @@ type UserRepository interface {
 
 	// Create creates a new user
 	Create(ctx context.Context, user *shared.User) error
+
+	// UpdateCreditLimit sets a user's credit limit; nil removes the limit
+	UpdateCreditLimit(ctx context.Context, userID uuid.UUID, creditLimit *shared.Money) error
 }
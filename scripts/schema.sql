@@ -8,6 +8,8 @@ CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
 CREATE TABLE IF NOT EXISTS users (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     name VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'admin')),
+    credit_limit DECIMAL(12,2) CHECK (credit_limit >= 0),
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
@@ -31,6 +33,20 @@ CREATE TABLE IF NOT EXISTS auctions (
     starting_price DECIMAL(10,2) NOT NULL CHECK (starting_price > 0),
     current_price DECIMAL(10,2) NOT NULL DEFAULT 0,
     status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'active', 'ended', 'cancelled')),
+    type VARCHAR(20) NOT NULL DEFAULT 'english' CHECK (type IN ('english', 'dutch', 'sealed_first_price', 'sealed_second_price', 'reverse')),
+    soft_close_seconds INTEGER NOT NULL DEFAULT 0 CHECK (soft_close_seconds >= 0),
+    reserve_price DECIMAL(10,2) CHECK (reserve_price IS NULL OR reserve_price > starting_price),
+    buy_now_price DECIMAL(10,2) CHECK (buy_now_price IS NULL OR buy_now_price > starting_price),
+    price_drop_amount DECIMAL(10,2) NOT NULL DEFAULT 0,
+    price_drop_interval_seconds INTEGER NOT NULL DEFAULT 0,
+    floor_price DECIMAL(10,2) NOT NULL DEFAULT 0,
+    increment_rule JSONB,
+    quantity INTEGER NOT NULL DEFAULT 1 CHECK (quantity > 0),
+    pricing VARCHAR(20) NOT NULL DEFAULT 'pay_as_bid' CHECK (pricing IN ('pay_as_bid', 'uniform')),
+    relist_policy JSONB,
+    relisted_from UUID REFERENCES auctions(id) ON DELETE SET NULL,
+    relist_count INTEGER NOT NULL DEFAULT 0 CHECK (relist_count >= 0),
+    currency CHAR(3) NOT NULL DEFAULT 'USD',
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
@@ -41,17 +57,105 @@ CREATE TABLE IF NOT EXISTS bids (
     auction_id UUID NOT NULL REFERENCES auctions(id) ON DELETE CASCADE,
     user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
     amount DECIMAL(10,2) NOT NULL CHECK (amount > 0),
-    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'rejected')),
+    quantity INTEGER NOT NULL DEFAULT 1 CHECK (quantity > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'rejected', 'retracted')),
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
 
+-- Proxy bids table (hidden maximum bids placed on behalf of users)
+CREATE TABLE IF NOT EXISTS proxy_bids (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    auction_id UUID NOT NULL REFERENCES auctions(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    max_amount DECIMAL(10,2) NOT NULL CHECK (max_amount > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (auction_id, user_id)
+);
+
+-- Bid retractions table (audit trail of withdrawn bids)
+CREATE TABLE IF NOT EXISTS bid_retractions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bid_id UUID NOT NULL UNIQUE REFERENCES bids(id) ON DELETE CASCADE,
+    auction_id UUID NOT NULL REFERENCES auctions(id) ON DELETE CASCADE,
+    retracted_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    previous_price DECIMAL(10,2) NOT NULL,
+    new_price DECIMAL(10,2) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Second-chance offers table (items offered to runner-ups after the winner defaulted)
+CREATE TABLE IF NOT EXISTS second_chance_offers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    auction_id UUID NOT NULL REFERENCES auctions(id) ON DELETE CASCADE,
+    bid_id UUID NOT NULL REFERENCES bids(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    amount DECIMAL(10,2) NOT NULL CHECK (amount > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'declined', 'expired')),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (auction_id, user_id)
+);
+
+-- Orders opened for auction winners and their payment state
+CREATE TABLE IF NOT EXISTS orders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    auction_id UUID NOT NULL REFERENCES auctions(id) ON DELETE CASCADE,
+    bid_id UUID NOT NULL REFERENCES bids(id) ON DELETE CASCADE,
+    buyer_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    seller_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_price DECIMAL(10,2) NOT NULL CHECK (unit_price > 0),
+    hammer_price DECIMAL(12,2) NOT NULL CHECK (hammer_price > 0),
+    buyer_premium DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (buyer_premium >= 0),
+    seller_fee DECIMAL(12,2) NOT NULL DEFAULT 0 CHECK (seller_fee >= 0),
+    seller_payout DECIMAL(12,2) NOT NULL,
+    amount DECIMAL(12,2) NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL DEFAULT 'USD',
+    status VARCHAR(20) NOT NULL DEFAULT 'awaiting_payment' CHECK (status IN ('awaiting_payment', 'processing', 'paid', 'failed', 'expired')),
+    payment_reference VARCHAR(255),
+    failure_reason TEXT,
+    due_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    paid_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    -- One order per winning bid: a multi-unit buyer may win units with several bids
+    UNIQUE (auction_id, bid_id)
+);
+
+-- Double-entry ledger: the entries of every transaction sum to zero
+CREATE TABLE IF NOT EXISTS ledger_transactions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    kind VARCHAR(30) NOT NULL CHECK (kind IN ('order_settlement')),
+    order_id UUID REFERENCES orders(id) ON DELETE RESTRICT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (kind, order_id)
+);
+
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    transaction_id UUID NOT NULL REFERENCES ledger_transactions(id) ON DELETE RESTRICT,
+    account_type VARCHAR(30) NOT NULL CHECK (account_type IN ('user', 'platform_fees', 'payment_clearing')),
+    user_id UUID REFERENCES users(id) ON DELETE RESTRICT,
+    kind VARCHAR(30) NOT NULL CHECK (kind IN ('payment', 'purchase', 'sale_proceeds', 'buyer_premium', 'seller_fee')),
+    amount DECIMAL(12,2) NOT NULL CHECK (amount <> 0),
+    currency CHAR(3) NOT NULL DEFAULT 'USD',
+    order_id UUID REFERENCES orders(id) ON DELETE RESTRICT,
+    auction_id UUID REFERENCES auctions(id) ON DELETE RESTRICT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((account_type = 'user') = (user_id IS NOT NULL))
+);
+
 -- Indexes for better performance
 CREATE INDEX IF NOT EXISTS idx_auctions_item_id ON auctions(item_id);
 CREATE INDEX IF NOT EXISTS idx_auctions_creator_id ON auctions(creator_id);
 CREATE INDEX IF NOT EXISTS idx_auctions_status ON auctions(status);
 CREATE INDEX IF NOT EXISTS idx_auctions_start_time ON auctions(start_time);
 CREATE INDEX IF NOT EXISTS idx_auctions_end_time ON auctions(end_time);
+CREATE INDEX IF NOT EXISTS idx_auctions_relisted_from ON auctions(relisted_from) WHERE relisted_from IS NOT NULL;
 
 -- NEW: Composite index for the new GetActiveByItemID query (most important for performance)
 CREATE INDEX IF NOT EXISTS idx_auctions_item_status ON auctions(item_id, status) WHERE status = 'active';
@@ -80,6 +184,21 @@ CREATE INDEX IF NOT EXISTS idx_bids_auction_created ON bids(auction_id, created_
 -- NEW: Index for user activity queries
 CREATE INDEX IF NOT EXISTS idx_bids_user_created ON bids(user_id, created_at DESC);
 
+-- Index for resolving competing proxy bids
+CREATE INDEX IF NOT EXISTS idx_proxy_bids_auction_max ON proxy_bids(auction_id, max_amount DESC);
+
+-- Index for auditing the retractions of an auction
+CREATE INDEX IF NOT EXISTS idx_bid_retractions_auction ON bid_retractions(auction_id, created_at DESC);
+
+-- Index for the offer history of an auction
+CREATE INDEX IF NOT EXISTS idx_second_chance_offers_auction ON second_chance_offers(auction_id, created_at);
+-- At most one offer per auction may be pending or accepted at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_second_chance_offers_open ON second_chance_offers(auction_id) WHERE status IN ('pending', 'accepted');
+CREATE INDEX IF NOT EXISTS idx_orders_auction ON orders(auction_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_orders_buyer ON orders(buyer_id);
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_user ON ledger_entries(user_id, created_at DESC) WHERE user_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_transaction ON ledger_entries(transaction_id);
+
 -- Function to update updated_at timestamp
 CREATE OR REPLACE FUNCTION update_updated_at_column()
 RETURNS TRIGGER AS $$
@@ -102,6 +221,15 @@ CREATE TRIGGER update_auctions_updated_at BEFORE UPDATE ON auctions
 CREATE TRIGGER update_bids_updated_at BEFORE UPDATE ON bids
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+CREATE TRIGGER update_proxy_bids_updated_at BEFORE UPDATE ON proxy_bids
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_second_chance_offers_updated_at BEFORE UPDATE ON second_chance_offers
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_orders_updated_at BEFORE UPDATE ON orders
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
 -- Sample data for testing
 INSERT INTO users (id, name) VALUES 
     ('550e8400-e29b-41d4-a716-446655440001', 'John'),
@@ -109,6 +237,10 @@ INSERT INTO users (id, name) VALUES
     ('550e8400-e29b-41d4-a716-446655440003', 'Bob')
 ON CONFLICT (id) DO NOTHING;
 
+INSERT INTO users (id, name, role) VALUES 
+    ('550e8400-e29b-41d4-a716-446655440000', 'Admin', 'admin')
+ON CONFLICT (id) DO NOTHING;
+
 INSERT INTO items (id, name, description) VALUES 
     ('660e8400-e29b-41d4-a716-446655440001', 'Vintage Watch', 'A beautiful vintage watch'),
     ('660e8400-e29b-41d4-a716-446655440002', 'Art Painting', 'Original oil painting by a famous artist'),